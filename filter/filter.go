@@ -1,9 +1,10 @@
 package filter
 
 import (
-	"fmt"
+	"context"
 	"math"
-	"regexp"
+	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/ramirofarias/prop-filter-cli/models"
@@ -11,142 +12,199 @@ import (
 
 type location = [2]float64
 
+const (
+	UnknownAmmenitiesAsFalse = "treat-as-false"
+	UnknownAmmenitiesExclude = "exclude"
+)
+
+// Comparison operators. OperatorOneOf matches any of Comparison.Values.
+const (
+	OperatorLess           = "lt"
+	OperatorLessOrEqual    = "lte"
+	OperatorGreater        = "gt"
+	OperatorGreaterOrEqual = "gte"
+	OperatorEqual          = "eq"
+	OperatorNotEqual       = "ne"
+	OperatorOneOf          = "oneof"
+)
+
 type Comparison struct {
-	Operator string
-	Value    float64
+	Operator  string
+	Value     float64
+	Values    []float64
+	Tolerance float64
+	// HasTolerance marks Tolerance as set even when it's 0, so an explicit
+	// ±0 asks for an exact match instead of falling back to the epsilon.
+	HasTolerance bool
 }
 
 type Filter struct {
-	SquareFootage []Comparison
-	Bathrooms     []Comparison
-	Rooms         []Comparison
-	Distance      []Comparison
-	Price         []Comparison
-	Lat           float64
-	Long          float64
-	Lighting      string
-	Keywords      []string
-	Ammenities    []string
+	SquareFootage     []Comparison
+	Bathrooms         []Comparison
+	Rooms             []Comparison
+	Distance          []Comparison
+	Price             []Comparison
+	Lat               float64
+	Long              float64
+	Lighting          []string
+	Keywords          []string
+	Ammenities        []string
+	AmmenitiesAny     []string
+	AmmenitiesNone    []string
+	UnknownAmmenities string
+	Epsilon           float64
+	// Glob patterns matched against the source file path or its base name.
+	Sources []string
 }
 
-func FilterProperties(properties []models.Property, filters Filter) []models.Property {
-	var filteredProperties []models.Property
+// FilterProperties returns the properties that match filters, in their
+// original order, splitting the work across GOMAXPROCS workers.
+func FilterProperties(properties []models.Property, filters Filter) ([]models.Property, error) {
+	return FilterPropertiesWorkers(properties, filters, runtime.GOMAXPROCS(0))
+}
 
-Filters:
-	for _, property := range properties {
-		if len(filters.SquareFootage) > 0 {
-			for _, comparison := range filters.SquareFootage {
-				if !matchesComparison(comparison, property.SquareFootage) {
-					continue Filters
-				}
-			}
-		}
-		if len(filters.Bathrooms) > 0 {
-			for _, comparison := range filters.Bathrooms {
-				if !matchesComparison(comparison, property.Bathrooms) {
-					continue Filters
-				}
-			}
-		}
-		if len(filters.Rooms) > 0 {
-			for _, comparison := range filters.Rooms {
-				if !matchesComparison(comparison, property.Rooms) {
-					continue Filters
-				}
-			}
+// FilterPropertiesWorkers is FilterProperties with the number of workers set
+// explicitly.
+func FilterPropertiesWorkers(properties []models.Property, filters Filter, workers int) ([]models.Property, error) {
+	return FilterPropertiesContext(context.Background(), properties, filters, workers)
+}
+
+// FilterPropertiesContext is FilterPropertiesWorkers that stops early and
+// returns ctx's error once ctx is done.
+func FilterPropertiesContext(ctx context.Context, properties []models.Property, filters Filter, workers int) ([]models.Property, error) {
+	matcher, err := Compile(filters)
+	if err != nil {
+		return nil, err
+	}
+	return matcher.FilterContext(ctx, properties, workers)
+}
+
+// Matches reports whether value satisfies c, with no epsilon applied. It
+// compiles c each time, so use Compile to check many values.
+func (c Comparison) Matches(value float64) (bool, error) {
+	matches, err := compileComparison(c, 0)
+	if err != nil {
+		return false, err
+	}
+	return matches(value), nil
+}
+
+func hasLighting(property models.Property, levels []string) bool {
+	for _, level := range levels {
+		if strings.EqualFold(strings.TrimSpace(property.Lighting), level) {
+			return true
 		}
-		if len(filters.Distance) > 0 {
-			if !(filters.Long == -999999) && !(filters.Lat == -999999) {
-				actualDistance := calculateDistance(filters.Lat, filters.Long, property.Location[0], property.Location[1])
-				for _, comparison := range filters.Distance {
-					if !matchesComparison(comparison, actualDistance) {
-						continue Filters
-					}
-				}
+	}
+	return false
+}
 
-			}
+func hasAnyAmmenity(property models.Property, ammenities []string) bool {
+	for _, keyword := range ammenities {
+		if property.Ammenities[keyword] {
+			return true
 		}
+	}
+	return false
+}
 
-		if len(filters.Price) > 0 {
-			for _, comparison := range filters.Price {
-				if !matchesComparison(comparison, property.Price) {
-					continue Filters
-				}
+func hasUnknownAmmenity(property models.Property, ammenityLists ...[]string) bool {
+	for _, ammenities := range ammenityLists {
+		for _, keyword := range ammenities {
+			if _, ok := property.Ammenities[keyword]; !ok {
+				return true
 			}
 		}
+	}
+	return false
+}
 
-		if filters.Lighting != "" {
-			if filters.Lighting != property.Lighting {
-				continue Filters
+// hasSource expects patterns that were checked by Compile.
+func hasSource(property models.Property, patterns []string) bool {
+	for _, pattern := range patterns {
+		for _, name := range []string{property.Source, filepath.Base(property.Source)} {
+			if matches, _ := filepath.Match(pattern, name); matches {
+				return true
 			}
 		}
+	}
+	return false
+}
 
-		if len(filters.Keywords) > 0 {
-			for _, keyword := range filters.Keywords {
-				if !hasKeyword(property.Description, keyword) {
-					continue Filters
-				}
+// hasKeyword reports whether the words of a keyword appear next to each other
+// in words. Both are split by search.Tokenize, like the text index, so the
+// index never drops a description this would match.
+func hasKeyword(words []string, keyword []string) bool {
+	for start := 0; start+len(keyword) <= len(words); start++ {
+		matches := true
+		for i, word := range keyword {
+			if words[start+i] != word {
+				matches = false
+				break
 			}
-
 		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
 
-		if len(filters.Ammenities) > 0 {
-			for _, keyword := range filters.Ammenities {
-				if !property.Ammenities[keyword] {
-					continue Filters
-				}
-			}
+func Distance(lat1, long1, lat2, long2 float64) float64 {
+	const EARTH_RADIUS = 6371
+	const RADIAN = math.Pi / 180
 
-		}
+	distance := 0.5 - math.Cos((lat2-lat1)*RADIAN)/2 + math.Cos(lat1*RADIAN)*math.Cos(lat2*RADIAN)*(1-math.Cos((long2-long1)*RADIAN))/2
 
-		filteredProperties = append(filteredProperties, property)
-	}
+	return 2 * EARTH_RADIUS * math.Asin(math.Sqrt(distance))
+}
 
-	return filteredProperties
+// tolerance returns the comparison's own tolerance if it has one, or epsilon.
+func (c Comparison) tolerance(epsilon float64) float64 {
+	if c.HasTolerance || c.Tolerance != 0 {
+		return c.Tolerance
+	}
+	return epsilon
 }
 
-func matchesComparison(comparison Comparison, prop float64) bool {
-	switch comparison.Operator {
-	case "lt":
-		if !(prop < comparison.Value) {
-			return false
-		}
-	case "gt":
-		if !(prop > comparison.Value) {
-			return false
+// RangeMayMatch reports whether any value between min and max could satisfy
+// all comparisons. It errs on the side of true, so a false result means no
+// value in the range matches.
+func RangeMayMatch(comparisons []Comparison, min, max float64, epsilon float64) bool {
+	low, high := min, max
+	for _, comparison := range comparisons {
+		tolerance := comparison.tolerance(epsilon)
+
+		switch comparison.Operator {
+		case "lt", "lte":
+			high = math.Min(high, comparison.Value)
+		case "gt", "gte":
+			low = math.Max(low, comparison.Value)
+		case "eq":
+			low = math.Max(low, comparison.Value-tolerance)
+			high = math.Min(high, comparison.Value+tolerance)
 		}
-	case "gte":
-		if !(prop >= comparison.Value) {
-			return false
+	}
+	if low > high {
+		return false
+	}
+
+	for _, comparison := range comparisons {
+		if comparison.Operator != "oneof" {
+			continue
 		}
-	case "lte":
-		if !(prop <= comparison.Value) {
-			return false
+		tolerance := comparison.tolerance(epsilon)
+
+		found := false
+		for _, value := range comparison.Values {
+			if value+tolerance >= low && value-tolerance <= high {
+				found = true
+				break
+			}
 		}
-	case "eq":
-		if !(prop == comparison.Value) {
+		if !found {
 			return false
 		}
-	default:
-		return true
 	}
 
 	return true
 }
-
-func hasKeyword(s string, k string) bool {
-	lowercaseString := strings.ToLower(s)
-	pattern := fmt.Sprintf(`\b%s\b`, regexp.QuoteMeta(k))
-	regex := regexp.MustCompile(pattern)
-
-	return regex.MatchString(lowercaseString)
-}
-
-func calculateDistance(lat1, long1, lat2, long2 float64) float64 {
-	const EARTH_RADIUS = 6371
-	const RADIAN = math.Pi / 180
-
-	distance := 0.5 - math.Cos((lat2-lat1)*RADIAN)/2 + math.Cos(lat1*RADIAN)*math.Cos(lat2*RADIAN)*(1-math.Cos((long2-long1)*RADIAN))/2
-
-	return 2 * EARTH_RADIUS * math.Asin(math.Sqrt(distance))
-}
@@ -1,10 +1,12 @@
 package filter
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/ramirofarias/prop-filter-cli/models"
+	"github.com/ramirofarias/prop-filter-cli/search"
 )
 
 func TestFilterProperties(t *testing.T) {
@@ -16,7 +18,8 @@ func TestFilterProperties(t *testing.T) {
 			Price:         300000,
 			Lighting:      "low",
 			Description:   "Spacious and bright apartment",
-			Ammenities:    map[string]bool{"pool": true, "gym": true},
+			Ammenities:    map[string]bool{"pool": true, "gym": true, "garage": false},
+			Source:        "data/north/listings.json",
 		},
 		{
 			SquareFootage: 750,
@@ -25,7 +28,8 @@ func TestFilterProperties(t *testing.T) {
 			Price:         200000,
 			Lighting:      "medium",
 			Description:   "Small house",
-			Ammenities:    map[string]bool{"gym": true},
+			Ammenities:    map[string]bool{"gym": true, "garage": true},
+			Source:        "data/south/listings.csv",
 		},
 	}
 
@@ -60,9 +64,14 @@ func TestFilterProperties(t *testing.T) {
 		},
 		{
 			name:     "Filter by lighting",
-			filters:  Filter{Lighting: "medium"},
+			filters:  Filter{Lighting: []string{"medium"}},
 			expected: []models.Property{properties[1]},
 		},
+		{
+			name:     "Filter by lighting is case-insensitive",
+			filters:  Filter{Lighting: []string{"LOW", "high"}},
+			expected: []models.Property{properties[0]},
+		},
 		{
 			name:     "Filter by keyword",
 			filters:  Filter{Keywords: []string{"spacious"}},
@@ -73,6 +82,66 @@ func TestFilterProperties(t *testing.T) {
 			filters:  Filter{Ammenities: []string{"pool"}},
 			expected: []models.Property{properties[0]},
 		},
+		{
+			name:     "Filter by any amenity",
+			filters:  Filter{AmmenitiesAny: []string{"pool", "sauna"}},
+			expected: []models.Property{properties[0]},
+		},
+		{
+			name:     "Filter by excluded amenity",
+			filters:  Filter{AmmenitiesNone: []string{"pool"}},
+			expected: []models.Property{properties[1]},
+		},
+		{
+			name:     "Missing amenity treated as false",
+			filters:  Filter{AmmenitiesNone: []string{"pool"}, UnknownAmmenities: UnknownAmmenitiesAsFalse},
+			expected: []models.Property{properties[1]},
+		},
+		{
+			name:     "Missing amenity excluded",
+			filters:  Filter{AmmenitiesNone: []string{"pool"}, UnknownAmmenities: UnknownAmmenitiesExclude},
+			expected: []models.Property{},
+		},
+		{
+			name:     "Amenity explicitly false is not unknown",
+			filters:  Filter{AmmenitiesNone: []string{"garage"}, UnknownAmmenities: UnknownAmmenitiesExclude},
+			expected: []models.Property{properties[0]},
+		},
+		{
+			name:     "Unknown any-of amenity ignored when another one matches",
+			filters:  Filter{AmmenitiesAny: []string{"pool", "sauna"}, UnknownAmmenities: UnknownAmmenitiesExclude},
+			expected: []models.Property{properties[0]},
+		},
+		{
+			name:     "All any-of amenities unknown excluded",
+			filters:  Filter{AmmenitiesAny: []string{"sauna", "jacuzzi"}, UnknownAmmenities: UnknownAmmenitiesExclude},
+			expected: []models.Property{},
+		},
+		{
+			name:     "Filter by price with epsilon",
+			filters:  Filter{Price: []Comparison{{Operator: "eq", Value: 200000.001}}, Epsilon: 0.01},
+			expected: []models.Property{properties[1]},
+		},
+		{
+			name:     "Explicit tolerance overrides epsilon",
+			filters:  Filter{Price: []Comparison{{Operator: "eq", Value: 200000.5, Tolerance: 1}}, Epsilon: 0.01},
+			expected: []models.Property{properties[1]},
+		},
+		{
+			name:     "Explicit zero tolerance overrides epsilon",
+			filters:  Filter{Price: []Comparison{{Operator: "eq", Value: 200000.001, HasTolerance: true}}, Epsilon: 0.01},
+			expected: nil,
+		},
+		{
+			name:     "Filter by source path",
+			filters:  Filter{Sources: []string{"data/south/*"}},
+			expected: []models.Property{properties[1]},
+		},
+		{
+			name:     "Filter by source file name",
+			filters:  Filter{Sources: []string{"*.json", "*.xlsx"}},
+			expected: []models.Property{properties[0]},
+		},
 		{
 			name:     "No matches",
 			filters:  Filter{SquareFootage: []Comparison{{Operator: "gt", Value: 5000}}},
@@ -87,7 +156,10 @@ func TestFilterProperties(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := FilterProperties(properties, tt.filters)
+			result, err := FilterProperties(properties, tt.filters)
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
 			if len(result) == 0 && len(tt.expected) == 0 {
 				return
 			}
@@ -99,7 +171,7 @@ func TestFilterProperties(t *testing.T) {
 	}
 }
 
-func TestMatchesComparison(t *testing.T) {
+func TestComparisonMatches(t *testing.T) {
 	tests := []struct {
 		comparison Comparison
 		value      float64
@@ -110,14 +182,30 @@ func TestMatchesComparison(t *testing.T) {
 		{Comparison{Operator: "gte", Value: 5}, 5, true},
 		{Comparison{Operator: "lte", Value: 5}, 6, false},
 		{Comparison{Operator: "eq", Value: 10}, 10, true},
+		{Comparison{Operator: "ne", Value: 10}, 10, false},
+		{Comparison{Operator: "ne", Value: 10}, 5, true},
+		{Comparison{Operator: "oneof", Values: []float64{1, 3, 5}}, 3, true},
+		{Comparison{Operator: "oneof", Values: []float64{1, 3, 5}}, 2, false},
+		{Comparison{Operator: "eq", Value: 250000, Tolerance: 0.01}, 250000.004, true},
+		{Comparison{Operator: "eq", Value: 250000, Tolerance: 0.01}, 250000.02, false},
+		{Comparison{Operator: "ne", Value: 250000, Tolerance: 0.01}, 250000.004, false},
+		{Comparison{Operator: "oneof", Values: []float64{1, 3}, Tolerance: 0.1}, 3.05, true},
+		{Comparison{Operator: "eq", Value: 10, HasTolerance: true}, 10, true},
 	}
 
 	for _, tt := range tests {
-		result := matchesComparison(tt.comparison, tt.value)
+		result, err := tt.comparison.Matches(tt.value)
+		if err != nil {
+			t.Errorf("did not expect error but got: %v", err)
+		}
 		if result != tt.expected {
 			t.Errorf("expected %v, got %v", tt.expected, result)
 		}
 	}
+
+	if _, err := (Comparison{Operator: "asd", Value: 1}).Matches(1); err == nil {
+		t.Errorf("expected error for unknown operator but got nil")
+	}
 }
 
 func TestHasKeyword(t *testing.T) {
@@ -129,12 +217,130 @@ func TestHasKeyword(t *testing.T) {
 		{"Foo bar", "foo", true},
 		{"This is a test", "foo", false},
 		{"This house has a gym", "gym", true},
+		{"Gymnasium nearby", "gym", false},
+		{"Close to the park, quiet street", "park quiet", true},
+		{"Quiet street near the park", "park quiet", false},
+		{"Cozy café on the ground floor", "café", true},
+		{"Pet-friendly building", "pet friendly", true},
 	}
 
 	for _, tt := range tests {
-		result := hasKeyword(tt.description, tt.keyword)
+		result := hasKeyword(search.Tokenize(tt.description), search.Tokenize(tt.keyword))
 		if result != tt.expected {
 			t.Errorf("expected %v, got %v", tt.expected, result)
 		}
 	}
 }
+
+func TestFilterPropertiesUnknownOperator(t *testing.T) {
+	properties := []models.Property{{Price: 1}}
+	_, err := FilterProperties(properties, Filter{Price: []Comparison{{Operator: "asd", Value: 1}}})
+	if err == nil {
+		t.Errorf("expected error but got nil")
+	}
+}
+
+func TestFilterPropertiesInvalidSource(t *testing.T) {
+	properties := []models.Property{{Source: "listings.csv"}}
+	_, err := FilterProperties(properties, Filter{Sources: []string{"[listings"}})
+	if err == nil {
+		t.Errorf("expected error but got nil")
+	}
+}
+
+func TestRangeMayMatch(t *testing.T) {
+	tests := []struct {
+		comparisons []Comparison
+		min, max    float64
+		expected    bool
+	}{
+		{[]Comparison{{Operator: "lt", Value: 100}}, 150, 300, false},
+		{[]Comparison{{Operator: "lt", Value: 200}}, 150, 300, true},
+		{[]Comparison{{Operator: "gte", Value: 300}}, 150, 300, true},
+		{[]Comparison{{Operator: "gt", Value: 400}}, 150, 300, false},
+		{[]Comparison{{Operator: "gte", Value: 100}, {Operator: "lt", Value: 140}}, 150, 300, false},
+		{[]Comparison{{Operator: "eq", Value: 310, Tolerance: 5}}, 150, 300, false},
+		{[]Comparison{{Operator: "eq", Value: 303, Tolerance: 5}}, 150, 300, true},
+		{[]Comparison{{Operator: "ne", Value: 200}}, 200, 200, true},
+		{[]Comparison{{Operator: "oneof", Values: []float64{50, 400}}}, 150, 300, false},
+		{[]Comparison{{Operator: "oneof", Values: []float64{50, 200}}}, 150, 300, true},
+	}
+
+	for _, tt := range tests {
+		result := RangeMayMatch(tt.comparisons, tt.min, tt.max, 0)
+		if result != tt.expected {
+			t.Errorf("%v in [%v, %v]: expected %v, got %v", tt.comparisons, tt.min, tt.max, tt.expected, result)
+		}
+	}
+}
+
+func benchmarkProperties(n int) []models.Property {
+	lighting := []string{"low", "medium", "high"}
+	descriptions := []string{"Spacious and bright apartment", "Small house near the park", "Quiet family home with garden"}
+
+	properties := make([]models.Property, n)
+	for i := range properties {
+		properties[i] = models.Property{
+			SquareFootage: float64(500 + i%3000),
+			Bathrooms:     float64(1 + i%4),
+			Rooms:         float64(1 + i%6),
+			Location:      [2]float64{-90 + float64(i%180), -180 + float64(i%360)},
+			Price:         float64(100000 + (i*7919)%900000),
+			Lighting:      lighting[i%len(lighting)],
+			Description:   descriptions[i%len(descriptions)],
+			Ammenities:    map[string]bool{"pool": i%2 == 0, "gym": i%3 == 0, "garage": i%5 == 0},
+		}
+	}
+	return properties
+}
+
+var benchmarkFilter = Filter{
+	SquareFootage: []Comparison{{Operator: "gt", Value: 1000}},
+	Price:         []Comparison{{Operator: "lt", Value: 600000}},
+	Lighting:      []string{"low", "high"},
+	Keywords:      []string{"bright"},
+	AmmenitiesAny: []string{"pool", "gym"},
+}
+
+func TestFilterPropertiesWorkers(t *testing.T) {
+	properties := benchmarkProperties(50000)
+	expected, err := FilterPropertiesWorkers(properties, benchmarkFilter, 1)
+	if err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+	if len(expected) == 0 {
+		t.Fatalf("expected some matches")
+	}
+
+	for _, workers := range []int{0, 2, 3, 8, 64} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			result, err := FilterPropertiesWorkers(properties, benchmarkFilter, workers)
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("expected %d properties in input order, got %d", len(expected), len(result))
+			}
+		})
+	}
+
+	_, err = FilterPropertiesWorkers(properties, Filter{Price: []Comparison{{Operator: "asd", Value: 1}}}, 4)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+	}
+}
+
+func BenchmarkFilterProperties(b *testing.B) {
+	properties := benchmarkProperties(1000000)
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := FilterPropertiesWorkers(properties, benchmarkFilter, workers); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(properties))*float64(b.N)/b.Elapsed().Seconds(), "properties/s")
+		})
+	}
+}
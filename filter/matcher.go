@@ -0,0 +1,263 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+	"github.com/ramirofarias/prop-filter-cli/search"
+)
+
+// Below this many properties per worker, the cost of starting goroutines and
+// merging results outweighs filtering in parallel.
+const minChunkSize = 4096
+
+// Filtering checks whether it was cancelled every this many properties.
+const contextCheckInterval = 1024
+
+type predicate func(property models.Property) bool
+
+type numberFilter struct {
+	comparisons []Comparison
+	value       func(models.Property) float64
+}
+
+// Matcher is a Filter compiled into predicates, so operators are resolved and
+// keywords are tokenized once rather than for every property. It's safe for
+// concurrent use and can be reused across datasets.
+type Matcher struct {
+	predicates []predicate
+}
+
+// Compile checks filters and compiles them into a Matcher.
+func Compile(filters Filter) (Matcher, error) {
+	var m Matcher
+
+	numbers := []numberFilter{
+		{filters.SquareFootage, func(p models.Property) float64 { return p.SquareFootage }},
+		{filters.Bathrooms, func(p models.Property) float64 { return p.Bathrooms }},
+		{filters.Rooms, func(p models.Property) float64 { return p.Rooms }},
+	}
+	if filters.Lat != -999999 && filters.Long != -999999 {
+		numbers = append(numbers, numberFilter{filters.Distance, func(p models.Property) float64 {
+			return Distance(filters.Lat, filters.Long, p.Location[0], p.Location[1])
+		}})
+	}
+	numbers = append(numbers, numberFilter{filters.Price, func(p models.Property) float64 { return p.Price }})
+
+	for _, number := range numbers {
+		if len(number.comparisons) == 0 {
+			continue
+		}
+		matches, err := compileComparisons(number.comparisons, filters.Epsilon)
+		if err != nil {
+			return Matcher{}, err
+		}
+		value := number.value
+		m.predicates = append(m.predicates, func(p models.Property) bool {
+			return matches(value(p))
+		})
+	}
+
+	if len(filters.Lighting) > 0 {
+		levels := filters.Lighting
+		m.predicates = append(m.predicates, func(p models.Property) bool {
+			return hasLighting(p, levels)
+		})
+	}
+
+	if len(filters.Keywords) > 0 {
+		keywords := make([][]string, len(filters.Keywords))
+		for i, keyword := range filters.Keywords {
+			keywords[i] = search.Tokenize(keyword)
+		}
+		m.predicates = append(m.predicates, func(p models.Property) bool {
+			words := search.Tokenize(p.Description)
+			for _, keyword := range keywords {
+				if !hasKeyword(words, keyword) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	if len(filters.Ammenities) > 0 {
+		ammenities := filters.Ammenities
+		m.predicates = append(m.predicates, func(p models.Property) bool {
+			for _, keyword := range ammenities {
+				if !p.Ammenities[keyword] {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	if len(filters.AmmenitiesAny) > 0 {
+		ammenities := filters.AmmenitiesAny
+		m.predicates = append(m.predicates, func(p models.Property) bool {
+			return hasAnyAmmenity(p, ammenities)
+		})
+	}
+
+	if len(filters.AmmenitiesNone) > 0 {
+		ammenities := filters.AmmenitiesNone
+		m.predicates = append(m.predicates, func(p models.Property) bool {
+			return !hasAnyAmmenity(p, ammenities)
+		})
+	}
+
+	if len(filters.Sources) > 0 {
+		for _, pattern := range filters.Sources {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return Matcher{}, fmt.Errorf("invalid source pattern %q: %v", pattern, err)
+			}
+		}
+		patterns := filters.Sources
+		m.predicates = append(m.predicates, func(p models.Property) bool {
+			return hasSource(p, patterns)
+		})
+	}
+
+	// A property whose any-of amenities are all unknown, or known but false,
+	// already fails the any-of check, so only the other lists are checked here.
+	if filters.UnknownAmmenities == UnknownAmmenitiesExclude {
+		lists := [][]string{filters.Ammenities, filters.AmmenitiesNone}
+		m.predicates = append(m.predicates, func(p models.Property) bool {
+			return !hasUnknownAmmenity(p, lists...)
+		})
+	}
+
+	return m, nil
+}
+
+func compileComparisons(comparisons []Comparison, epsilon float64) (func(float64) bool, error) {
+	checks := make([]func(float64) bool, len(comparisons))
+	for i, comparison := range comparisons {
+		check, err := compileComparison(comparison, epsilon)
+		if err != nil {
+			return nil, err
+		}
+		checks[i] = check
+	}
+
+	if len(checks) == 1 {
+		return checks[0], nil
+	}
+	return func(value float64) bool {
+		for _, check := range checks {
+			if !check(value) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func compileComparison(comparison Comparison, epsilon float64) (func(float64) bool, error) {
+	target := comparison.Value
+	tolerance := comparison.tolerance(epsilon)
+
+	switch comparison.Operator {
+	case OperatorLess:
+		return func(value float64) bool { return value < target }, nil
+	case OperatorGreater:
+		return func(value float64) bool { return value > target }, nil
+	case OperatorGreaterOrEqual:
+		return func(value float64) bool { return value >= target }, nil
+	case OperatorLessOrEqual:
+		return func(value float64) bool { return value <= target }, nil
+	case OperatorEqual:
+		return func(value float64) bool { return math.Abs(value-target) <= tolerance }, nil
+	case OperatorNotEqual:
+		return func(value float64) bool { return math.Abs(value-target) > tolerance }, nil
+	case OperatorOneOf:
+		values := comparison.Values
+		return func(value float64) bool {
+			for _, v := range values {
+				if math.Abs(value-v) <= tolerance {
+					return true
+				}
+			}
+			return false
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown comparison operator: %s", comparison.Operator)
+	}
+}
+
+// Match reports whether property matches every filter.
+func (m Matcher) Match(property models.Property) bool {
+	for _, matches := range m.predicates {
+		if !matches(property) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the properties that match, in their original order, splitting
+// the work across GOMAXPROCS workers.
+func (m Matcher) Filter(properties []models.Property) []models.Property {
+	return m.FilterWorkers(properties, runtime.GOMAXPROCS(0))
+}
+
+// FilterWorkers is Filter with the number of workers set explicitly.
+func (m Matcher) FilterWorkers(properties []models.Property, workers int) []models.Property {
+	filteredProperties, _ := m.FilterContext(context.Background(), properties, workers)
+	return filteredProperties
+}
+
+// FilterContext is FilterWorkers that stops early and returns ctx's error once
+// ctx is done. Each worker filters a contiguous chunk of properties, and the
+// chunks are merged back in order.
+func (m Matcher) FilterContext(ctx context.Context, properties []models.Property, workers int) ([]models.Property, error) {
+	workers = min(workers, len(properties)/minChunkSize)
+	if workers <= 1 {
+		return m.filterChunk(ctx, properties)
+	}
+
+	results := make([][]models.Property, workers)
+	errs := make([]error, workers)
+	chunkSize := (len(properties) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		chunk := properties[min(w*chunkSize, len(properties)):min((w+1)*chunkSize, len(properties))]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[w], errs[w] = m.filterChunk(ctx, chunk)
+		}()
+	}
+	wg.Wait()
+
+	var filteredProperties []models.Property
+	for w, result := range results {
+		if errs[w] != nil {
+			return nil, errs[w]
+		}
+		filteredProperties = append(filteredProperties, result...)
+	}
+	return filteredProperties, nil
+}
+
+func (m Matcher) filterChunk(ctx context.Context, properties []models.Property) ([]models.Property, error) {
+	var filteredProperties []models.Property
+	for i, property := range properties {
+		if i%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if m.Match(property) {
+			filteredProperties = append(filteredProperties, property)
+		}
+	}
+	return filteredProperties, nil
+}
@@ -0,0 +1,87 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters Filter
+		wantErr bool
+	}{
+		{name: "Empty filter", filters: Filter{}},
+		{name: "Valid comparisons", filters: Filter{Price: []Comparison{{Operator: "lt", Value: 1}, {Operator: "oneof", Values: []float64{1, 2}}}}},
+		{name: "Unknown operator", filters: Filter{Rooms: []Comparison{{Operator: "asd", Value: 1}}}, wantErr: true},
+		{name: "Invalid source pattern", filters: Filter{Sources: []string{"[listings"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.filters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMatcherReuse(t *testing.T) {
+	matcher, err := Compile(Filter{
+		Price:    []Comparison{{Operator: "eq", Value: 200000}},
+		Keywords: []string{"house"},
+		Epsilon:  1000,
+	})
+	if err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	datasets := []struct {
+		properties []models.Property
+		expected   []models.Property
+	}{
+		{
+			properties: []models.Property{
+				{Price: 200500, Description: "Small House"},
+				{Price: 200500, Description: "Small apartment"},
+			},
+			expected: []models.Property{{Price: 200500, Description: "Small House"}},
+		},
+		{
+			properties: []models.Property{
+				{Price: 250000, Description: "Big house"},
+				{Price: 199000, Description: "Townhouse or house"},
+			},
+			expected: []models.Property{{Price: 199000, Description: "Townhouse or house"}},
+		},
+	}
+
+	for _, dataset := range datasets {
+		result := matcher.Filter(dataset.properties)
+		if !reflect.DeepEqual(result, dataset.expected) {
+			t.Errorf("expected %v, got %v", dataset.expected, result)
+		}
+	}
+}
+
+func TestMatcherFilterContextCancelled(t *testing.T) {
+	matcher, err := Compile(Filter{})
+	if err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	properties := make([]models.Property, 4*minChunkSize)
+	for _, workers := range []int{1, 4} {
+		if _, err := matcher.FilterContext(ctx, properties, workers); !errors.Is(err, context.Canceled) {
+			t.Errorf("%d workers: expected context.Canceled, got %v", workers, err)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package index
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ramirofarias/prop-filter-cli/filter"
+	"github.com/ramirofarias/prop-filter-cli/models"
+	"github.com/ramirofarias/prop-filter-cli/output"
+	"github.com/ramirofarias/prop-filter-cli/search"
+)
+
+const magic = "PFIDX1\n"
+
+type Ammenity struct {
+	Name    string
+	Present []uint64
+	Value   []uint64
+}
+
+type Index struct {
+	Source        string
+	Checksum      string
+	SourceSize    int64
+	SourceModTime time.Time
+
+	Count         int
+	SquareFootage []float64
+	Lighting      []string
+	Price         []float64
+	Rooms         []float64
+	Bathrooms     []float64
+	Latitude      []float64
+	Longitude     []float64
+	Descriptions  []string
+	Ammenities    []Ammenity
+	Text          *search.Index
+}
+
+func Build(properties []models.Property, source string) (*Index, error) {
+	source, err := filepath.Abs(source)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving source path: %v", err)
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("error reading source file: %v", err)
+	}
+	checksum, err := fileChecksum(source)
+	if err != nil {
+		return nil, err
+	}
+
+	count := len(properties)
+	idx := &Index{
+		Source:        source,
+		Checksum:      checksum,
+		SourceSize:    info.Size(),
+		SourceModTime: info.ModTime(),
+		Count:         count,
+		SquareFootage: make([]float64, count),
+		Lighting:      make([]string, count),
+		Price:         make([]float64, count),
+		Rooms:         make([]float64, count),
+		Bathrooms:     make([]float64, count),
+		Latitude:      make([]float64, count),
+		Longitude:     make([]float64, count),
+		Descriptions:  make([]string, count),
+		Text:          search.FromProperties(properties),
+	}
+
+	ammenities := map[string]*Ammenity{}
+	words := (count + 63) / 64
+	for i, property := range properties {
+		idx.SquareFootage[i] = property.SquareFootage
+		idx.Lighting[i] = property.Lighting
+		idx.Price[i] = property.Price
+		idx.Rooms[i] = property.Rooms
+		idx.Bathrooms[i] = property.Bathrooms
+		idx.Latitude[i] = property.Location[0]
+		idx.Longitude[i] = property.Location[1]
+		idx.Descriptions[i] = property.Description
+
+		for name, value := range property.Ammenities {
+			ammenity, ok := ammenities[name]
+			if !ok {
+				ammenity = &Ammenity{Name: name, Present: make([]uint64, words), Value: make([]uint64, words)}
+				ammenities[name] = ammenity
+			}
+			ammenity.Present[i/64] |= 1 << (i % 64)
+			if value {
+				ammenity.Value[i/64] |= 1 << (i % 64)
+			}
+		}
+	}
+
+	for _, ammenity := range ammenities {
+		idx.Ammenities = append(idx.Ammenities, *ammenity)
+	}
+	sort.Slice(idx.Ammenities, func(i, j int) bool {
+		return idx.Ammenities[i].Name < idx.Ammenities[j].Name
+	})
+
+	return idx, nil
+}
+
+// Write saves idx to path through a temporary file, so a failed write or a
+// cancelled ctx leaves no partial index behind. An existing file is only
+// replaced when overwrite is set.
+func Write(ctx context.Context, idx *Index, path string, overwrite bool) error {
+	return output.WriteFile(ctx, path, output.Options{Overwrite: overwrite}, func(w io.Writer) error {
+		writer := bufio.NewWriter(w)
+		if _, err := writer.WriteString(magic); err != nil {
+			return fmt.Errorf("error writing index file: %v", err)
+		}
+		if err := gob.NewEncoder(writer).Encode(idx); err != nil {
+			return fmt.Errorf("error encoding index: %v", err)
+		}
+		if err := writer.Flush(); err != nil {
+			return fmt.Errorf("error writing index file: %v", err)
+		}
+		return nil
+	})
+}
+
+func Read(path string) (*Index, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening index file: %v", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(reader, header); err != nil || string(header) != magic {
+		return nil, fmt.Errorf("%s is not a prop-filter-cli index file", path)
+	}
+
+	var idx Index
+	if err := gob.NewDecoder(reader).Decode(&idx); err != nil {
+		return nil, fmt.Errorf("error decoding index: %v", err)
+	}
+
+	return &idx, nil
+}
+
+func (idx *Index) Stale() (bool, error) {
+	info, err := os.Stat(idx.Source)
+	if err != nil {
+		return false, fmt.Errorf("error reading source file: %v", err)
+	}
+	if info.Size() == idx.SourceSize && info.ModTime().Equal(idx.SourceModTime) {
+		return false, nil
+	}
+
+	checksum, err := fileChecksum(idx.Source)
+	if err != nil {
+		return false, err
+	}
+
+	return checksum != idx.Checksum, nil
+}
+
+// Candidates returns the documents that may match filters, in order, using the
+// stored columns, amenity bitmaps and text postings instead of decoding every
+// property. It's a superset of the matches, to be filtered again once decoded,
+// or nil when filters don't narrow the documents down.
+func (idx *Index) Candidates(filters filter.Filter) ([]int, error) {
+	words := (idx.Count + 63) / 64
+	candidates := make([]uint64, words)
+	for i := range candidates {
+		candidates[i] = ^uint64(0)
+	}
+	if idx.Count%64 != 0 {
+		candidates[words-1] = 1<<(idx.Count%64) - 1
+	}
+	pruned := false
+
+	for _, keyword := range filters.Keywords {
+		for _, term := range search.Tokenize(keyword) {
+			docs := make([]uint64, words)
+			for _, posting := range idx.Text.Postings[term] {
+				docs[posting.Doc/64] |= 1 << (posting.Doc % 64)
+			}
+			and(candidates, docs)
+			pruned = true
+		}
+	}
+
+	for _, name := range filters.Ammenities {
+		and(candidates, idx.ammenityValues(name, words))
+		pruned = true
+	}
+	if len(filters.AmmenitiesAny) > 0 {
+		anyOf := make([]uint64, words)
+		for _, name := range filters.AmmenitiesAny {
+			or(anyOf, idx.ammenityValues(name, words))
+		}
+		and(candidates, anyOf)
+		pruned = true
+	}
+	for _, name := range filters.AmmenitiesNone {
+		andNot(candidates, idx.ammenityValues(name, words))
+		pruned = true
+	}
+
+	// The remaining filters only need the scalar columns, so they're checked
+	// against properties built without amenities or a description.
+	scalars := filter.Filter{
+		SquareFootage: filters.SquareFootage,
+		Bathrooms:     filters.Bathrooms,
+		Rooms:         filters.Rooms,
+		Distance:      filters.Distance,
+		Price:         filters.Price,
+		Lat:           filters.Lat,
+		Long:          filters.Long,
+		Lighting:      filters.Lighting,
+		Epsilon:       filters.Epsilon,
+	}
+	if len(scalars.SquareFootage)+len(scalars.Bathrooms)+len(scalars.Rooms)+len(scalars.Distance)+len(scalars.Price)+len(scalars.Lighting) > 0 {
+		matcher, err := filter.Compile(scalars)
+		if err != nil {
+			return nil, err
+		}
+		for doc := 0; doc < idx.Count; doc++ {
+			if candidates[doc/64]&(1<<(doc%64)) == 0 {
+				continue
+			}
+			property := models.Property{
+				SquareFootage: idx.SquareFootage[doc],
+				Lighting:      idx.Lighting[doc],
+				Price:         idx.Price[doc],
+				Rooms:         idx.Rooms[doc],
+				Bathrooms:     idx.Bathrooms[doc],
+				Location:      [2]float64{idx.Latitude[doc], idx.Longitude[doc]},
+			}
+			if !matcher.Match(property) {
+				candidates[doc/64] &^= 1 << (doc % 64)
+			}
+		}
+		pruned = true
+	}
+
+	if !pruned {
+		return nil, nil
+	}
+
+	docs := []int{}
+	for doc := 0; doc < idx.Count; doc++ {
+		if candidates[doc/64]&(1<<(doc%64)) != 0 {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// ammenityValues returns the bitmap of documents where name is true.
+func (idx *Index) ammenityValues(name string, words int) []uint64 {
+	for _, ammenity := range idx.Ammenities {
+		if ammenity.Name == name {
+			return ammenity.Value
+		}
+	}
+	return make([]uint64, words)
+}
+
+func (idx *Index) Properties(docs []int) []models.Property {
+	if docs == nil {
+		docs = make([]int, idx.Count)
+		for i := range docs {
+			docs[i] = i
+		}
+	}
+
+	properties := make([]models.Property, len(docs))
+	for i, doc := range docs {
+		properties[i] = models.Property{
+			SquareFootage: idx.SquareFootage[doc],
+			Lighting:      idx.Lighting[doc],
+			Price:         idx.Price[doc],
+			Rooms:         idx.Rooms[doc],
+			Bathrooms:     idx.Bathrooms[doc],
+			Location:      [2]float64{idx.Latitude[doc], idx.Longitude[doc]},
+			Description:   idx.Descriptions[doc],
+			Ammenities:    map[string]bool{},
+			Source:        idx.Source,
+		}
+		for _, ammenity := range idx.Ammenities {
+			if ammenity.Present[doc/64]&(1<<(doc%64)) != 0 {
+				properties[i].Ammenities[ammenity.Name] = ammenity.Value[doc/64]&(1<<(doc%64)) != 0
+			}
+		}
+	}
+
+	return properties
+}
+
+func and(a, b []uint64) {
+	for i := range a {
+		a[i] &= b[i]
+	}
+}
+
+func or(a, b []uint64) {
+	for i := range a {
+		a[i] |= b[i]
+	}
+}
+
+func andNot(a, b []uint64) {
+	for i := range a {
+		a[i] &^= b[i]
+	}
+}
+
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error opening source file: %v", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("error hashing source file: %v", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,160 @@
+package index
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/filter"
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestIndexRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "properties.json")
+	if err := os.WriteFile(source, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	properties := []models.Property{
+		{
+			SquareFootage: 1000,
+			Lighting:      "low",
+			Price:         300000,
+			Rooms:         3,
+			Bathrooms:     2,
+			Location:      [2]float64{40.7128, -74.0060},
+			Description:   "Spacious and bright apartment",
+			Ammenities:    map[string]bool{"pool": true, "gym": false},
+		},
+		{
+			SquareFootage: 750,
+			Lighting:      "medium",
+			Price:         200000,
+			Rooms:         1,
+			Bathrooms:     1,
+			Location:      [2]float64{-34.5749, -58.4303},
+			Description:   "Small bright house",
+			Ammenities:    map[string]bool{"gym": true},
+		},
+	}
+
+	idx, err := Build(properties, source)
+	if err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	path := filepath.Join(dir, "properties.idx")
+	if err := Write(context.Background(), idx, path, false); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+	if err := Write(context.Background(), idx, path, false); err == nil {
+		t.Errorf("expected error overwriting without overwrite but got nil")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Write(ctx, idx, path, true); err == nil {
+		t.Errorf("expected error with a cancelled context but got nil")
+	}
+	idx, err = Read(path)
+	if err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	for i := range properties {
+		properties[i].Source = idx.Source
+	}
+	if result := idx.Properties(nil); !reflect.DeepEqual(result, properties) {
+		t.Errorf("expected %v, got %v", properties, result)
+	}
+
+	if stale, err := idx.Stale(); err != nil || stale {
+		t.Errorf("expected fresh index, got stale=%v err=%v", stale, err)
+	}
+	if err := os.WriteFile(source, []byte("[ ]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if stale, err := idx.Stale(); err != nil || !stale {
+		t.Errorf("expected stale index, got stale=%v err=%v", stale, err)
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "properties.json")
+	if err := os.WriteFile(source, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	properties := []models.Property{
+		{Price: 300000, Rooms: 3, Lighting: "high", Location: [2]float64{34.05, -118.24}, Description: "Spacious and bright apartment", Ammenities: map[string]bool{"pool": true, "garage": false}},
+		{Price: 150000, Rooms: 1, Lighting: "medium", Location: [2]float64{34.06, -118.25}, Description: "Small bright house near a café", Ammenities: map[string]bool{"garage": true}},
+		{Price: 90000, Rooms: 2, Lighting: "low", Location: [2]float64{40.71, -74.0}, Description: "Dark basement", Ammenities: map[string]bool{}},
+	}
+	for i := 3; i < 70; i++ {
+		properties = append(properties, models.Property{Price: float64(i * 1000), Rooms: 1, Lighting: "low", Description: "Filler", Ammenities: map[string]bool{"yard": i%2 == 0}})
+	}
+	idx, err := Build(properties, source)
+	if err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	noLocation := filter.Filter{Lat: -999999, Long: -999999}
+	with := func(f func(*filter.Filter)) filter.Filter {
+		filters := noLocation
+		f(&filters)
+		return filters
+	}
+
+	tests := []struct {
+		name     string
+		filters  filter.Filter
+		expected []int
+	}{
+		{"no filters", noLocation, nil},
+		{"keyword", with(func(f *filter.Filter) { f.Keywords = []string{"bright"} }), []int{0, 1}},
+		{"keywords", with(func(f *filter.Filter) { f.Keywords = []string{"bright", "house"} }), []int{1}},
+		{"non-ASCII keyword", with(func(f *filter.Filter) { f.Keywords = []string{"café"} }), []int{1}},
+		{"missing keyword", with(func(f *filter.Filter) { f.Keywords = []string{"garden"} }), []int{}},
+		{"price", with(func(f *filter.Filter) { f.Price = []filter.Comparison{{Operator: "gte", Value: 69000}} }), []int{0, 1, 2, 69}},
+		{"lighting", with(func(f *filter.Filter) { f.Lighting = []string{"medium", "high"} }), []int{0, 1}},
+		{"amenities", with(func(f *filter.Filter) { f.Ammenities = []string{"garage"} }), []int{1}},
+		{"amenities any", with(func(f *filter.Filter) { f.AmmenitiesAny = []string{"pool", "garage", "sauna"} }), []int{0, 1}},
+		{"amenities none", with(func(f *filter.Filter) {
+			f.AmmenitiesNone = []string{"yard"}
+			f.Price = []filter.Comparison{{Operator: "gt", Value: 65000}}
+		}), []int{0, 1, 2, 67, 69}},
+		{"distance", filter.Filter{Lat: 34.05, Long: -118.24, Distance: []filter.Comparison{{Operator: "lt", Value: 5}}}, []int{0, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := idx.Candidates(tt.filters)
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+
+			// Every property the filter matches must be a candidate.
+			matches, err := filter.FilterProperties(idx.Properties(nil), tt.filters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			candidates, err := filter.FilterProperties(idx.Properties(result), tt.filters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(candidates, matches) {
+				t.Errorf("expected candidates to keep matches %v, got %v", matches, candidates)
+			}
+		})
+	}
+
+	if _, err := idx.Candidates(with(func(f *filter.Filter) { f.Price = []filter.Comparison{{Operator: "asd"}} })); err == nil {
+		t.Errorf("expected error for unknown operator but got nil")
+	}
+}
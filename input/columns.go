@@ -0,0 +1,55 @@
+package input
+
+import "strings"
+
+var columnNames = map[string]string{
+	"squarefootage": "squareFootage",
+	"sqft":          "squareFootage",
+	"lighting":      "lighting",
+	"price":         "price",
+	"rooms":         "rooms",
+	"bathrooms":     "bathrooms",
+	"baths":         "bathrooms",
+	"latitude":      "latitude",
+	"lat":           "latitude",
+	"longitude":     "longitude",
+	"long":          "longitude",
+	"lng":           "longitude",
+	"description":   "description",
+	"ammenities":    "ammenities",
+	"amenities":     "ammenities",
+	"score":         "score",
+	"source":        "source",
+	"location":      "location",
+	"key":           "key",
+}
+
+var requiredColumns = []string{"squareFootage", "price", "rooms", "bathrooms", "latitude", "longitude"}
+
+// headerColumns maps known column names to their index. Any other named column
+// is returned separately under its lowercased name, as a possible amenity.
+func headerColumns(header []string) (map[string]int, map[string]int) {
+	columnIndex := map[string]int{}
+	otherColumns := map[string]int{}
+	for i, cell := range header {
+		name := strings.TrimSpace(cell)
+		if name == "" {
+			continue
+		}
+		if column, ok := columnNames[strings.ToLower(name)]; ok {
+			columnIndex[column] = i
+		} else {
+			otherColumns[strings.ToLower(name)] = i
+		}
+	}
+	return columnIndex, otherColumns
+}
+
+func hasColumns(columnIndex map[string]int, columns []string) bool {
+	for _, column := range columns {
+		if _, ok := columnIndex[column]; !ok {
+			return false
+		}
+	}
+	return true
+}
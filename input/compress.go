@@ -0,0 +1,64 @@
+package input
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+func (r readCloser) Close() error {
+	return r.close()
+}
+
+// Open opens filename for reading. Gzip and zstd content is detected by its
+// magic bytes rather than the extension and decompressed as it's read.
+// Uncompressed files are returned as the *os.File itself.
+func Open(filename string) (io.ReadCloser, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+
+	magic := make([]byte, len(zstdMagic))
+	n, _ := file.ReadAt(magic, 0)
+	magic = magic[:n]
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		decompressor, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("error reading gzip data: %v", err)
+		}
+		return readCloser{decompressor, func() error {
+			decompressor.Close()
+			return file.Close()
+		}}, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		decompressor, err := zstd.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("error reading zstd data: %v", err)
+		}
+		return readCloser{decompressor, func() error {
+			decompressor.Close()
+			return file.Close()
+		}}, nil
+	default:
+		return file, nil
+	}
+}
@@ -0,0 +1,24 @@
+package input
+
+import (
+	"context"
+	"io"
+)
+
+// contextReader stops reading once its context is done, so decoders reading
+// from it return promptly.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+func withContext(ctx context.Context, r io.Reader) io.Reader {
+	return contextReader{ctx, r}
+}
@@ -1,38 +1,69 @@
 package input
 
 import (
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
+	"strings"
 
 	"github.com/ramirofarias/prop-filter-cli/models"
 )
 
-func FromCSVFile(filename string) ([]models.Property, error) {
-	file, err := os.Open(filename)
+func FromCSVFile(ctx context.Context, filename string) ([]models.Property, error) {
+	file, err := Open(filename)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file: %v", err)
+		return nil, err
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
+	return FromCSV(ctx, file)
+}
+
+// FromCSV reads properties one row at a time from CSV with a header row.
+func FromCSV(ctx context.Context, r io.Reader) ([]models.Property, error) {
+	r = withContext(ctx, r)
+	reader := csv.NewReader(r)
 
-	records, err := reader.ReadAll()
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("error reading CSV data: missing header row")
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error reading CSV data: %v", err)
 	}
 
-	header := records[0]
-	columnIndex := map[string]int{}
-	for i, column := range header {
-		columnIndex[column] = i
+	// Headers are mapped like XLSX, SQLite and GeoJSON columns, so "sqft" or
+	// "Amenities" are read too.
+	seen := map[string]string{}
+	for _, name := range header {
+		column, ok := columnNames[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			continue
+		}
+		if other, ok := seen[column]; ok {
+			return nil, fmt.Errorf("CSV header has both %q and %q columns", other, name)
+		}
+		seen[column] = name
+	}
+	columnIndex, _ := headerColumns(header)
+	if !hasColumns(columnIndex, requiredColumns) {
+		return nil, fmt.Errorf("CSV header is missing columns, expected: %s", strings.Join(requiredColumns, ", "))
 	}
 
 	var properties []models.Property
 
-	for _, record := range records[1:] {
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading CSV data: %v", err)
+		}
+
 		property := models.Property{}
 
 		sqft, err := strconv.ParseFloat(record[columnIndex["squareFootage"]], 0)
@@ -41,7 +72,9 @@ func FromCSVFile(filename string) ([]models.Property, error) {
 		}
 		property.SquareFootage = float64(sqft)
 
-		property.Lighting = record[columnIndex["lighting"]]
+		if i, ok := columnIndex["lighting"]; ok {
+			property.Lighting = record[i]
+		}
 
 		property.Price, err = strconv.ParseFloat(record[columnIndex["price"]], 64)
 		if err != nil {
@@ -69,12 +102,15 @@ func FromCSVFile(filename string) ([]models.Property, error) {
 			return nil, fmt.Errorf("invalid longitude value: %v", err)
 		}
 
-		property.Description = record[columnIndex["description"]]
+		if i, ok := columnIndex["description"]; ok {
+			property.Description = record[i]
+		}
 
-		ammenitiesJSON := record[columnIndex["ammenities"]]
-		err = json.Unmarshal([]byte(ammenitiesJSON), &property.Ammenities)
-		if err != nil {
-			return nil, fmt.Errorf("invalid ammenities JSON: %v", err)
+		property.Ammenities = map[string]bool{}
+		if i, ok := columnIndex["ammenities"]; ok {
+			if err := json.Unmarshal([]byte(record[i]), &property.Ammenities); err != nil {
+				return nil, fmt.Errorf("invalid ammenities JSON: %v", err)
+			}
 		}
 
 		properties = append(properties, property)
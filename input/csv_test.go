@@ -0,0 +1,73 @@
+package input
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestFromCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  []models.Property
+		expectErr bool
+	}{
+		{
+			name: "Exact headers",
+			input: "squareFootage,lighting,price,rooms,bathrooms,latitude,longitude,description,ammenities\n" +
+				`1200,high,250000,3,2,34.05,-118.24,Loft,"{""pool"":true}"` + "\n",
+			expected: []models.Property{
+				{SquareFootage: 1200, Lighting: "high", Price: 250000, Rooms: 3, Bathrooms: 2, Location: [2]float64{34.05, -118.24}, Description: "Loft", Ammenities: map[string]bool{"pool": true}},
+			},
+		},
+		{
+			name:  "Aliases in any order and case",
+			input: "Price,SQFT,Baths,Rooms,Lng,Lat,Amenities\n" + `250000,1200,2,3,-118.24,34.05,"{""pool"":true}"` + "\n",
+			expected: []models.Property{
+				{SquareFootage: 1200, Price: 250000, Rooms: 3, Bathrooms: 2, Location: [2]float64{34.05, -118.24}, Ammenities: map[string]bool{"pool": true}},
+			},
+		},
+		{
+			name:     "Optional columns missing",
+			input:    "squareFootage,price,rooms,bathrooms,latitude,longitude,score\n800,90000,1,1,40.71,-74,0.5\n",
+			expected: []models.Property{{SquareFootage: 800, Price: 90000, Rooms: 1, Bathrooms: 1, Location: [2]float64{40.71, -74}, Ammenities: map[string]bool{}}},
+		},
+		{
+			name:      "Missing required column",
+			input:     "squareFootage,price,rooms,bathrooms,latitude\n800,90000,1,1,40.71\n",
+			expectErr: true,
+		},
+		{
+			name:      "Both amenities columns",
+			input:     "squareFootage,price,rooms,bathrooms,latitude,longitude,ammenities,amenities\n800,90000,1,1,40.71,-74,{},{}\n",
+			expectErr: true,
+		},
+		{
+			name:      "Missing header row",
+			input:     "",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := FromCSV(context.Background(), strings.NewReader(test.input))
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package input
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// Expand resolves glob patterns into file paths, in the order the patterns are
+// given and sorted within each pattern. Paths without glob characters are kept
+// as they are, so a missing file is reported when it's read. Files matched by
+// more than one pattern are only listed once.
+func Expand(patterns []string) ([]string, error) {
+	var paths []string
+	seen := map[string]bool{}
+
+	for _, pattern := range patterns {
+		matches := []string{pattern}
+		if strings.ContainsAny(pattern, `*?[\`) {
+			var err error
+			matches, err = filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid input pattern %q: %v", pattern, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no input files match %q", pattern)
+			}
+		}
+
+		for _, path := range matches {
+			if !seen[filepath.Clean(path)] {
+				seen[filepath.Clean(path)] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+// Walk lists the files in dir, and in its subdirectories when recursive is set,
+// in lexical order. Hidden files and directories are skipped. When include is
+// set only files matching one of its patterns are listed, and files matching
+// an exclude pattern never are. Patterns are matched against the path relative
+// to dir and against the file name.
+func Walk(dir string, recursive bool, include, exclude []string) ([]string, error) {
+	var paths []string
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			if !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if len(include) > 0 {
+			included, err := matchesAny(include, relative)
+			if err != nil || !included {
+				return err
+			}
+		}
+		excluded, err := matchesAny(exclude, relative)
+		if err != nil || excluded {
+			return err
+		}
+
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading input directory %s: %v", dir, err)
+	}
+
+	return paths, nil
+}
+
+func matchesAny(patterns []string, path string) (bool, error) {
+	for _, pattern := range patterns {
+		for _, name := range []string{path, filepath.Base(path)} {
+			matches, err := filepath.Match(pattern, name)
+			if err != nil {
+				return false, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+			}
+			if matches {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
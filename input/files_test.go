@@ -0,0 +1,106 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"b.csv",
+		"a.json",
+		"draft-a.csv",
+		".hidden.csv",
+		"north/2026-10-01.csv",
+		"north/notes.txt",
+		"north/deep/c.csv",
+		".git/config.csv",
+		"south/.cache/d.csv",
+		"south/a.csv",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		recursive bool
+		include   []string
+		exclude   []string
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "top level only",
+			expected: []string{"a.json", "b.csv", "draft-a.csv"},
+		},
+		{
+			name:      "recursive in lexical order",
+			recursive: true,
+			expected:  []string{"a.json", "b.csv", "draft-a.csv", "north/2026-10-01.csv", "north/deep/c.csv", "north/notes.txt", "south/a.csv"},
+		},
+		{
+			name:      "include by file name",
+			recursive: true,
+			include:   []string{"*.csv"},
+			expected:  []string{"b.csv", "draft-a.csv", "north/2026-10-01.csv", "north/deep/c.csv", "south/a.csv"},
+		},
+		{
+			name:      "include by relative path",
+			recursive: true,
+			include:   []string{"north/*"},
+			expected:  []string{"north/2026-10-01.csv", "north/notes.txt"},
+		},
+		{
+			name:      "exclude wins over include",
+			recursive: true,
+			include:   []string{"*.csv"},
+			exclude:   []string{"draft-*", "deep/*", "north/deep/*"},
+			expected:  []string{"b.csv", "north/2026-10-01.csv", "south/a.csv"},
+		},
+		{
+			name:      "invalid pattern",
+			include:   []string{"["},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paths, err := Walk(dir, test.recursive, test.include, test.exclude)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+
+			var relative []string
+			for _, path := range paths {
+				rel, err := filepath.Rel(dir, path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				relative = append(relative, filepath.ToSlash(rel))
+			}
+			if !reflect.DeepEqual(relative, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, relative)
+			}
+		})
+	}
+
+	if _, err := Walk(filepath.Join(dir, "missing"), false, nil, nil); err == nil {
+		t.Errorf("expected error for missing directory but got nil")
+	}
+}
@@ -0,0 +1,141 @@
+package input
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+type geoJSONObject struct {
+	Type       string          `json:"type"`
+	Features   []geoJSONObject `json:"features"`
+	Geometry   *geoJSONObject  `json:"geometry"`
+	Properties json.RawMessage `json:"properties"`
+	// Point coordinates are [longitude, latitude], other geometries nest deeper.
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+func FromGeoJSONFile(ctx context.Context, filename string) ([]models.Property, error) {
+	file, err := Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return FromGeoJSON(ctx, file)
+}
+
+func FromGeoJSON(ctx context.Context, r io.Reader) ([]models.Property, error) {
+	r = withContext(ctx, r)
+	var object geoJSONObject
+	if err := json.NewDecoder(r).Decode(&object); err != nil {
+		return nil, fmt.Errorf("error unmarshaling GeoJSON: %v", err)
+	}
+
+	var features []geoJSONObject
+	switch object.Type {
+	case "FeatureCollection":
+		features = object.Features
+	case "Feature":
+		features = []geoJSONObject{object}
+	default:
+		return nil, fmt.Errorf("unsupported GeoJSON type %q, expected FeatureCollection or Feature", object.Type)
+	}
+
+	var properties []models.Property
+	for i, feature := range features {
+		property, err := propertyFromFeature(feature)
+		if err != nil {
+			return nil, fmt.Errorf("feature %d: %v", i+1, err)
+		}
+		properties = append(properties, property)
+	}
+
+	return properties, nil
+}
+
+func propertyFromFeature(feature geoJSONObject) (models.Property, error) {
+	var property models.Property
+	if feature.Type != "Feature" {
+		return property, fmt.Errorf("unsupported type %q, expected Feature", feature.Type)
+	}
+	if feature.Geometry == nil {
+		return property, fmt.Errorf("missing geometry")
+	}
+	if feature.Geometry.Type != "Point" {
+		return property, fmt.Errorf("unsupported geometry type %q, expected Point", feature.Geometry.Type)
+	}
+
+	var coordinates []float64
+	if err := json.Unmarshal(feature.Geometry.Coordinates, &coordinates); err != nil || len(coordinates) < 2 {
+		return property, fmt.Errorf("invalid Point coordinates: %s", feature.Geometry.Coordinates)
+	}
+
+	if len(feature.Properties) > 0 && string(feature.Properties) != "null" {
+		if err := readFeatureProperties(feature.Properties, &property); err != nil {
+			return property, fmt.Errorf("invalid properties: %v", err)
+		}
+	}
+	property.Location = [2]float64{coordinates[1], coordinates[0]}
+
+	return property, nil
+}
+
+// readFeatureProperties maps feature properties to property fields using the
+// same names and aliases as tabular input. Keys that don't name a field, like
+// a feed's own id or url, are ignored, and the location always comes from the
+// geometry.
+func readFeatureProperties(data json.RawMessage, property *models.Property) error {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	seen := map[string]string{}
+	for _, key := range keys {
+		value := values[key]
+		column, ok := columnNames[strings.ToLower(strings.TrimSpace(key))]
+		if !ok || string(value) == "null" {
+			continue
+		}
+		if other, ok := seen[column]; ok {
+			return fmt.Errorf("both %q and %q set %s", other, key, column)
+		}
+		seen[column] = key
+
+		var target interface{}
+		switch column {
+		case "squareFootage":
+			target = &property.SquareFootage
+		case "lighting":
+			target = &property.Lighting
+		case "price":
+			target = &property.Price
+		case "rooms":
+			target = &property.Rooms
+		case "bathrooms":
+			target = &property.Bathrooms
+		case "description":
+			target = &property.Description
+		case "ammenities":
+			target = &property.Ammenities
+		default:
+			continue
+		}
+		if err := json.Unmarshal(value, target); err != nil {
+			return fmt.Errorf("invalid %s value: %s", key, value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package input
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestFromGeoJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []models.Property
+		err      string
+	}{
+		{
+			name: "feature collection",
+			input: `{"type": "FeatureCollection", "features": [
+				{"type": "Feature", "geometry": {"type": "Point", "coordinates": [-118.24, 34.05]},
+				 "properties": {"squareFootage": 1200, "lighting": "high", "price": 250000, "rooms": 3, "bathrooms": 2,
+				  "description": "Bright loft", "ammenities": {"pool": true}}}
+			]}`,
+			expected: []models.Property{
+				{SquareFootage: 1200, Lighting: "high", Price: 250000, Rooms: 3, Bathrooms: 2, Location: [2]float64{34.05, -118.24}, Description: "Bright loft", Ammenities: map[string]bool{"pool": true}},
+			},
+		},
+		{
+			name: "aliases and extra keys",
+			input: `{"type": "Feature", "id": 7, "geometry": {"type": "Point", "coordinates": [-74.0, 40.71]},
+				"properties": {"id": "abc-123", "name": "Studio", "url": "https://example.com/7", "SqFt": 500, "price": 90000,
+				 "rooms": 1, "baths": 1, "lat": 0, "amenities": {"yard": true}}}`,
+			expected: []models.Property{
+				{SquareFootage: 500, Price: 90000, Rooms: 1, Bathrooms: 1, Location: [2]float64{40.71, -74.0}, Ammenities: map[string]bool{"yard": true}},
+			},
+		},
+		{
+			name:     "null properties",
+			input:    `{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": null}`,
+			expected: []models.Property{{Location: [2]float64{2, 1}}},
+		},
+		{
+			name:  "duplicate alias",
+			input: `{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": {"sqft": 1, "squareFootage": 2}}`,
+			err:   `both "sqft" and "squareFootage" set squareFootage`,
+		},
+		{
+			name:  "invalid value",
+			input: `{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": {"price": "cheap"}}`,
+			err:   `invalid price value: "cheap"`,
+		},
+		{
+			name:  "polygon",
+			input: `{"type": "Feature", "geometry": {"type": "Polygon", "coordinates": [[[1, 2], [3, 4], [1, 2]]]}}`,
+			err:   `feature 1: unsupported geometry type "Polygon", expected Point`,
+		},
+		{
+			name:  "geometry collection",
+			input: `{"type": "GeometryCollection", "geometries": []}`,
+			err:   `unsupported GeoJSON type "GeometryCollection"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := FromGeoJSON(context.Background(), strings.NewReader(test.input))
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("expected error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
@@ -1,32 +1,54 @@
 package input
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/ramirofarias/prop-filter-cli/models"
 )
 
-func FromJSONFile(filename string) ([]models.Property, error) {
-	var properties []models.Property
-
-	file, err := os.Open(filename)
+func FromJSONFile(ctx context.Context, filename string) ([]models.Property, error) {
+	file, err := Open(filename)
 	if err != nil {
 		return nil, err
-
 	}
 	defer file.Close()
 
-	data, _ := io.ReadAll(file)
-	decoder := json.NewDecoder(bytes.NewReader(data))
+	return FromJSON(ctx, file)
+}
+
+// FromJSON decodes a JSON array of properties one element at a time, so the
+// whole document is never held in memory.
+func FromJSON(ctx context.Context, r io.Reader) ([]models.Property, error) {
+	r = withContext(ctx, r)
+	decoder := json.NewDecoder(r)
 	decoder.DisallowUnknownFields()
-	err = decoder.Decode(&properties)
 
+	token, err := decoder.Token()
 	if err != nil {
-		return []models.Property{}, fmt.Errorf("error unmarshaling json: %e", err)
+		return nil, fmt.Errorf("error unmarshaling json: %v", err)
+	}
+	if token == nil {
+		return nil, nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("error unmarshaling json: expected an array of properties")
 	}
+
+	properties := []models.Property{}
+	for decoder.More() {
+		var property models.Property
+		if err := decoder.Decode(&property); err != nil {
+			return nil, fmt.Errorf("error unmarshaling json: record %d: %v", len(properties)+1, err)
+		}
+		properties = append(properties, property)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("error unmarshaling json: %v", err)
+	}
+
 	return properties, nil
 }
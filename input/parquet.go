@@ -0,0 +1,345 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/ramirofarias/prop-filter-cli/filter"
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+// Parquet columns holding each Property field, in schema order.
+var parquetColumns = []struct {
+	field   string
+	columns []string
+}{
+	{"squareFootage", []string{"squareFootage"}},
+	{"lighting", []string{"lighting"}},
+	{"price", []string{"price"}},
+	{"rooms", []string{"rooms"}},
+	{"bathrooms", []string{"bathrooms"}},
+	{"location", []string{"latitude", "longitude"}},
+	{"description", []string{"description"}},
+	{"ammenities", []string{"ammenities"}},
+}
+
+var parquetSetters = map[string]func(*models.ParquetProperty, parquet.Value){
+	"squareFootage": func(p *models.ParquetProperty, v parquet.Value) { p.SquareFootage = parquetNumber(v) },
+	"lighting":      func(p *models.ParquetProperty, v parquet.Value) { p.Lighting = string(v.ByteArray()) },
+	"price":         func(p *models.ParquetProperty, v parquet.Value) { p.Price = parquetNumber(v) },
+	"rooms":         func(p *models.ParquetProperty, v parquet.Value) { p.Rooms = parquetNumber(v) },
+	"bathrooms":     func(p *models.ParquetProperty, v parquet.Value) { p.Bathrooms = parquetNumber(v) },
+	"latitude":      func(p *models.ParquetProperty, v parquet.Value) { p.Latitude = parquetNumber(v) },
+	"longitude":     func(p *models.ParquetProperty, v parquet.Value) { p.Longitude = parquetNumber(v) },
+	"description":   func(p *models.ParquetProperty, v parquet.Value) { p.Description = string(v.ByteArray()) },
+}
+
+// FromParquetFile reads properties from a Parquet file. Only the Property
+// fields listed in columns are read, or all of them when columns is nil. Row
+// groups whose column statistics show they can't match the numeric filters
+// are skipped, the remaining rows still have to go through the filters.
+func FromParquetFile(ctx context.Context, filename string, filters filter.Filter, columns []string) ([]models.Property, error) {
+	reader, err := Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	// Parquet needs random access, so compressed files are read into memory.
+	var data io.ReaderAt
+	var size int64
+	if file, ok := reader.(*os.File); ok {
+		info, err := file.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("error reading file info: %v", err)
+		}
+		data, size = file, info.Size()
+	} else {
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing file: %v", err)
+		}
+		data, size = bytes.NewReader(content), int64(len(content))
+	}
+
+	return FromParquet(ctx, data, size, filters, columns)
+}
+
+// FromParquet reads properties from Parquet data of the given size, like
+// FromParquetFile.
+func FromParquet(ctx context.Context, data io.ReaderAt, size int64, filters filter.Filter, columns []string) ([]models.Property, error) {
+	parquetFile, err := parquet.OpenFile(data, size)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Parquet file: %v", err)
+	}
+
+	names, err := projectedColumns(parquetFile.Schema(), columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var properties []models.Property
+
+	for i, rowGroup := range parquetFile.RowGroups() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !rowGroupMayMatch(parquetFile, i, filters) {
+			continue
+		}
+
+		rows := make([]models.ParquetProperty, rowGroup.NumRows())
+		for _, name := range names {
+			if name == "ammenities" {
+				err = readParquetMap(parquetFile.Schema(), rowGroup, rows)
+			} else {
+				err = readParquetColumn(parquetFile.Schema(), rowGroup, name, rows)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("row group %d: %v", i, err)
+			}
+		}
+
+		for _, row := range rows {
+			properties = append(properties, row.Property())
+		}
+	}
+
+	return properties, nil
+}
+
+// projectedColumns returns the Parquet columns to read for the given Property
+// fields. Required columns must be in the file, optional ones are left empty
+// when they aren't.
+func projectedColumns(schema *parquet.Schema, fields []string) ([]string, error) {
+	wanted := map[string]bool{}
+	for _, field := range fields {
+		wanted[field] = true
+	}
+
+	required := map[string]bool{}
+	for _, column := range requiredColumns {
+		required[column] = true
+	}
+
+	var names []string
+	for _, column := range parquetColumns {
+		if fields != nil && !wanted[column.field] {
+			continue
+		}
+		delete(wanted, column.field)
+
+		for _, name := range column.columns {
+			leaf, ok := schema.Lookup(name)
+			switch {
+			case name == "ammenities":
+				ok = hasParquetField(schema, name)
+			case !ok:
+			case name == "lighting" || name == "description":
+				if leaf.Node.Type().Kind() != parquet.ByteArray {
+					return nil, fmt.Errorf("column %s is not a string", name)
+				}
+			default:
+				if !isParquetNumber(leaf.Node.Type().Kind()) {
+					return nil, fmt.Errorf("column %s is not numeric", name)
+				}
+			}
+
+			if ok {
+				names = append(names, name)
+			} else if required[name] {
+				return nil, fmt.Errorf("missing column: %s", name)
+			}
+		}
+	}
+
+	for field := range wanted {
+		return nil, fmt.Errorf("unknown column: %s", field)
+	}
+
+	return names, nil
+}
+
+func readParquetColumn(schema *parquet.Schema, rowGroup parquet.RowGroup, name string, rows []models.ParquetProperty) error {
+	leaf, _ := schema.Lookup(name)
+	values, err := readParquetValues(rowGroup.ColumnChunks()[leaf.ColumnIndex])
+	if err != nil {
+		return fmt.Errorf("error reading column %s: %v", name, err)
+	}
+	if len(values) != len(rows) {
+		return fmt.Errorf("column %s has %d values for %d rows", name, len(values), len(rows))
+	}
+
+	set := parquetSetters[name]
+	for i, value := range values {
+		if !value.IsNull() {
+			set(&rows[i], value)
+		}
+	}
+
+	return nil
+}
+
+// readParquetMap reads the ammenities map, stored as a repeated group of key
+// and value columns. A repetition level of 0 starts the next row, and a key
+// below the maximum definition level marks an empty or null map.
+func readParquetMap(schema *parquet.Schema, rowGroup parquet.RowGroup, rows []models.ParquetProperty) error {
+	var entries string
+	for _, field := range schema.Fields() {
+		if field.Name() == "ammenities" && len(field.Fields()) == 1 {
+			entries = field.Fields()[0].Name()
+		}
+	}
+
+	keyLeaf, keyOk := schema.Lookup("ammenities", entries, "key")
+	valueLeaf, valueOk := schema.Lookup("ammenities", entries, "value")
+	if !keyOk || !valueOk || keyLeaf.Node.Type().Kind() != parquet.ByteArray || valueLeaf.Node.Type().Kind() != parquet.Boolean {
+		return fmt.Errorf("column ammenities is not a map of strings to booleans")
+	}
+
+	keys, err := readParquetValues(rowGroup.ColumnChunks()[keyLeaf.ColumnIndex])
+	if err != nil {
+		return fmt.Errorf("error reading ammenities keys: %v", err)
+	}
+	values, err := readParquetValues(rowGroup.ColumnChunks()[valueLeaf.ColumnIndex])
+	if err != nil {
+		return fmt.Errorf("error reading ammenities values: %v", err)
+	}
+	if len(keys) != len(values) {
+		return fmt.Errorf("ammenities has %d keys and %d values", len(keys), len(values))
+	}
+
+	row := -1
+	for i, key := range keys {
+		if key.RepetitionLevel() == 0 {
+			row++
+		}
+		if row >= len(rows) {
+			return fmt.Errorf("ammenities has more entries than rows")
+		}
+		if key.DefinitionLevel() < keyLeaf.MaxDefinitionLevel {
+			continue
+		}
+
+		if rows[row].Ammenities == nil {
+			rows[row].Ammenities = map[string]bool{}
+		}
+		rows[row].Ammenities[string(key.ByteArray())] = !values[i].IsNull() && values[i].Boolean()
+	}
+
+	return nil
+}
+
+func readParquetValues(chunk parquet.ColumnChunk) ([]parquet.Value, error) {
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	var values []parquet.Value
+	for {
+		page, err := pages.ReadPage()
+		if errors.Is(err, io.EOF) {
+			return values, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		buffer := make([]parquet.Value, page.NumValues())
+		reader := page.Values()
+		read := 0
+		for read < len(buffer) {
+			n, err := reader.ReadValues(buffer[read:])
+			read += n
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		values = append(values, buffer[:read]...)
+	}
+}
+
+func hasParquetField(schema *parquet.Schema, name string) bool {
+	for _, field := range schema.Fields() {
+		if field.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isParquetNumber(kind parquet.Kind) bool {
+	switch kind {
+	case parquet.Double, parquet.Float, parquet.Int32, parquet.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func parquetNumber(value parquet.Value) float64 {
+	switch value.Kind() {
+	case parquet.Float:
+		return float64(value.Float())
+	case parquet.Int32:
+		return float64(value.Int32())
+	case parquet.Int64:
+		return float64(value.Int64())
+	default:
+		return value.Double()
+	}
+}
+
+func rowGroupMayMatch(file *parquet.File, rowGroup int, filters filter.Filter) bool {
+	numeric := []struct {
+		column      string
+		comparisons []filter.Comparison
+	}{
+		{"squareFootage", filters.SquareFootage},
+		{"price", filters.Price},
+		{"rooms", filters.Rooms},
+		{"bathrooms", filters.Bathrooms},
+	}
+
+	for _, n := range numeric {
+		if len(n.comparisons) == 0 {
+			continue
+		}
+		min, max, ok := columnBounds(file, rowGroup, n.column)
+		if !ok {
+			continue
+		}
+		if !filter.RangeMayMatch(n.comparisons, min, max, filters.Epsilon) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// columnBounds returns the min and max statistics of a double column in a row
+// group, if the file has them.
+func columnBounds(file *parquet.File, rowGroup int, column string) (float64, float64, bool) {
+	leaf, ok := file.Schema().Lookup(column)
+	if !ok || leaf.Node.Type().Kind() != parquet.Double {
+		return 0, 0, false
+	}
+
+	statistics := file.Metadata().RowGroups[rowGroup].Columns[leaf.ColumnIndex].MetaData.Statistics
+	if len(statistics.MinValue) != 8 || len(statistics.MaxValue) != 8 {
+		return 0, 0, false
+	}
+
+	min := math.Float64frombits(binary.LittleEndian.Uint64(statistics.MinValue))
+	max := math.Float64frombits(binary.LittleEndian.Uint64(statistics.MaxValue))
+	return min, max, true
+}
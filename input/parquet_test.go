@@ -0,0 +1,100 @@
+package input
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/ramirofarias/prop-filter-cli/filter"
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestFromParquetFile(t *testing.T) {
+	expected := []models.Property{
+		{SquareFootage: 1200, Lighting: "high", Price: 250000, Rooms: 3, Bathrooms: 2, Location: [2]float64{34.05, -118.24}, Description: "Bright loft", Ammenities: map[string]bool{"pool": true, "garage": false}},
+		{SquareFootage: 800, Lighting: "low", Price: 90000, Rooms: 1, Bathrooms: 1, Location: [2]float64{40.71, -74.0}, Description: "Studio", Ammenities: map[string]bool{}},
+	}
+
+	path := filepath.Join(t.TempDir(), "in.parquet")
+	rows := []models.ParquetProperty{models.NewParquetProperty(expected[0]), models.NewParquetProperty(expected[1])}
+	if err := parquet.WriteFile(path, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := FromParquetFile(context.Background(), path, filter.Filter{}, nil)
+	if err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestFromParquetFilePushdown(t *testing.T) {
+	rows := []models.ParquetProperty{
+		{Price: 100, Rooms: 1, Description: "a", Ammenities: map[string]bool{"pool": true, "yard": false}},
+		{Price: 200, Rooms: 2, Description: "b"},
+		{Price: 500, Rooms: 3, Description: "c", Ammenities: map[string]bool{"garage": true}},
+		{Price: 600, Rooms: 4, Description: "d"},
+	}
+
+	path := filepath.Join(t.TempDir(), "in.parquet")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := parquet.NewGenericWriter[models.ParquetProperty](file, parquet.MaxRowsPerRowGroup(2))
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	tests := []struct {
+		name     string
+		filters  filter.Filter
+		columns  []string
+		expected []float64
+	}{
+		{"no filters", filter.Filter{}, nil, []float64{100, 200, 500, 600}},
+		{"skips second row group", filter.Filter{Price: []filter.Comparison{{Operator: "lt", Value: 300}}}, nil, []float64{100, 200}},
+		{"keeps row group with a partial match", filter.Filter{Rooms: []filter.Comparison{{Operator: "gte", Value: 2}}}, nil, []float64{100, 200, 500, 600}},
+		{"skips every row group", filter.Filter{Price: []filter.Comparison{{Operator: "oneof", Values: []float64{300, 400}}}}, nil, nil},
+		{"projection", filter.Filter{}, []string{"price", "ammenities"}, []float64{100, 200, 500, 600}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FromParquetFile(context.Background(), path, tt.filters, tt.columns)
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+
+			var prices []float64
+			for _, property := range result {
+				prices = append(prices, property.Price)
+			}
+			if !reflect.DeepEqual(prices, tt.expected) {
+				t.Errorf("expected prices %v, got %v", tt.expected, prices)
+			}
+
+			if tt.columns != nil {
+				if result[0].Description != "" || result[0].Rooms != 0 {
+					t.Errorf("expected unprojected columns to be empty, got %+v", result[0])
+				}
+				if !reflect.DeepEqual(result[0].Ammenities, rows[0].Ammenities) || !reflect.DeepEqual(result[2].Ammenities, rows[2].Ammenities) {
+					t.Errorf("expected amenities to be read, got %v and %v", result[0].Ammenities, result[2].Ammenities)
+				}
+			}
+		})
+	}
+
+	if _, err := FromParquetFile(context.Background(), path, filter.Filter{}, []string{"garden"}); err == nil {
+		t.Errorf("expected error for unknown column but got nil")
+	}
+}
@@ -0,0 +1,206 @@
+package input
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+	_ "modernc.org/sqlite"
+)
+
+const defaultSQLiteTable = "properties"
+
+// FromSQLiteFile reads properties from a table, or from the rows of a query.
+// Result columns are matched by name like CSV headers. Amenities come from an
+// ammenities JSON column, or from the property_amenities table written by the
+// SQLite output when the result has a key column.
+func FromSQLiteFile(ctx context.Context, filename string, table string, query string) ([]models.Property, error) {
+	if table != "" && query != "" {
+		return nil, fmt.Errorf("a table and a query can't be used together")
+	}
+	if query == "" {
+		if table == "" {
+			table = defaultSQLiteTable
+		}
+		query = fmt.Sprintf(`SELECT * FROM "%s"`, strings.ReplaceAll(table, `"`, `""`))
+	}
+
+	file, err := Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	_, uncompressed := file.(*os.File)
+	file.Close()
+	if !uncompressed {
+		return nil, fmt.Errorf("compressed SQLite files aren't supported, decompress the file first")
+	}
+	db, err := sql.Open("sqlite", "file:"+url.PathEscape(filename)+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying database: %v", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("error reading columns: %v", err)
+	}
+	columnIndex, _ := headerColumns(columns)
+	if !hasColumns(columnIndex, requiredColumns) {
+		return nil, fmt.Errorf("query result is missing columns, expected: %s", strings.Join(requiredColumns, ", "))
+	}
+	keyColumn, hasKey := columnIndex["key"]
+
+	var properties []models.Property
+	var keys []string
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	for row := 1; rows.Next(); row++ {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("row %d: %v", row, err)
+		}
+
+		number := func(column string) (float64, error) {
+			value, err := sqlNumber(values[columnIndex[column]])
+			if err != nil {
+				return 0, fmt.Errorf("row %d: invalid %s value: %v", row, column, err)
+			}
+			return value, nil
+		}
+
+		property := models.Property{}
+
+		if property.SquareFootage, err = number("squareFootage"); err != nil {
+			return nil, err
+		}
+		if property.Price, err = number("price"); err != nil {
+			return nil, err
+		}
+		if property.Rooms, err = number("rooms"); err != nil {
+			return nil, err
+		}
+		if property.Bathrooms, err = number("bathrooms"); err != nil {
+			return nil, err
+		}
+		if property.Location[0], err = number("latitude"); err != nil {
+			return nil, err
+		}
+		if property.Location[1], err = number("longitude"); err != nil {
+			return nil, err
+		}
+
+		if i, ok := columnIndex["lighting"]; ok {
+			property.Lighting = sqlText(values[i])
+		}
+		if i, ok := columnIndex["description"]; ok {
+			property.Description = sqlText(values[i])
+		}
+
+		property.Ammenities = map[string]bool{}
+		if i, ok := columnIndex["ammenities"]; ok && sqlText(values[i]) != "" {
+			if err := json.Unmarshal([]byte(sqlText(values[i])), &property.Ammenities); err != nil {
+				return nil, fmt.Errorf("row %d: invalid ammenities JSON: %v", row, err)
+			}
+		}
+
+		properties = append(properties, property)
+		if hasKey {
+			keys = append(keys, sqlText(values[keyColumn]))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %v", err)
+	}
+
+	if hasKey {
+		if err := readSQLiteAmmenities(ctx, db, properties, keys); err != nil {
+			return nil, err
+		}
+	}
+
+	return properties, nil
+}
+
+func readSQLiteAmmenities(ctx context.Context, db *sql.DB, properties []models.Property, keys []string) error {
+	var exists int
+	err := db.QueryRowContext(ctx, `SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'property_amenities'`).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("error looking up amenities table: %v", err)
+	}
+	if exists == 0 {
+		return nil
+	}
+
+	byKey := map[string][]int{}
+	for i, key := range keys {
+		byKey[key] = append(byKey[key], i)
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT propertyKey, name, present FROM property_amenities`)
+	if err != nil {
+		return fmt.Errorf("error querying amenities: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, name string
+		var present bool
+		if err := rows.Scan(&key, &name, &present); err != nil {
+			return fmt.Errorf("error reading amenity: %v", err)
+		}
+		for _, i := range byKey[key] {
+			properties[i].Ammenities[name] = present
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error reading amenities: %v", err)
+	}
+
+	return nil
+}
+
+func sqlNumber(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	case []byte:
+		return strconv.ParseFloat(strings.TrimSpace(string(v)), 64)
+	case nil:
+		return 0, fmt.Errorf("value is NULL")
+	default:
+		return 0, fmt.Errorf("unexpected type %T", value)
+	}
+}
+
+func sqlText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
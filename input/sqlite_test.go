@@ -0,0 +1,71 @@
+package input
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestFromSQLiteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "in.sqlite")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`
+CREATE TABLE properties (key TEXT PRIMARY KEY, squareFootage REAL, lighting TEXT, price REAL, rooms INTEGER, bathrooms INTEGER, latitude REAL, longitude REAL, description TEXT);
+CREATE TABLE property_amenities (propertyKey TEXT, name TEXT, present INTEGER);
+CREATE TABLE listings (SQFT TEXT, Price REAL, Rooms REAL, Baths REAL, Lat REAL, Lng REAL, ammenities TEXT);
+INSERT INTO properties VALUES ('a', 1200, 'high', 240000, 3, 2, 34.05, -118.24, 'Bright loft'), ('b', 800, 'low', 90000, 1, 1, 40.71, -74.0, 'Studio');
+INSERT INTO property_amenities VALUES ('a', 'pool', 1), ('b', 'yard', 1), ('b', 'garage', 0);
+INSERT INTO listings VALUES ('500', 70000, 1, 1, 1.5, 2.5, '{"pool":true}');
+`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loft := models.Property{SquareFootage: 1200, Lighting: "high", Price: 240000, Rooms: 3, Bathrooms: 2, Location: [2]float64{34.05, -118.24}, Description: "Bright loft", Ammenities: map[string]bool{"pool": true}}
+	studio := models.Property{SquareFootage: 800, Lighting: "low", Price: 90000, Rooms: 1, Bathrooms: 1, Location: [2]float64{40.71, -74.0}, Description: "Studio", Ammenities: map[string]bool{"yard": true, "garage": false}}
+
+	tests := []struct {
+		name     string
+		table    string
+		query    string
+		expected []models.Property
+		err      bool
+	}{
+		{name: "default table", expected: []models.Property{loft, studio}},
+		{name: "query", query: "SELECT * FROM properties WHERE price < 100000", expected: []models.Property{studio}},
+		{
+			name:     "aliased columns",
+			table:    "listings",
+			expected: []models.Property{{SquareFootage: 500, Price: 70000, Rooms: 1, Bathrooms: 1, Location: [2]float64{1.5, 2.5}, Ammenities: map[string]bool{"pool": true}}},
+		},
+		{name: "missing table", table: "sales", err: true},
+		{name: "missing columns", query: "SELECT price FROM properties", err: true},
+		{name: "table and query", table: "properties", query: "SELECT * FROM properties", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := FromSQLiteFile(context.Background(), path, test.table, test.query)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
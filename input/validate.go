@@ -0,0 +1,32 @@
+package input
+
+import (
+	"fmt"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+// Validate returns one warning per unknown lighting value, with the number of
+// records that have it, in the order the values first appear.
+func Validate(properties []models.Property) []string {
+	var values []string
+	records := map[string][]int{}
+	for i, property := range properties {
+		if _, ok := models.LightingRank(property.Lighting); !ok {
+			if _, seen := records[property.Lighting]; !seen {
+				values = append(values, property.Lighting)
+			}
+			records[property.Lighting] = append(records[property.Lighting], i+1)
+		}
+	}
+
+	var warnings []string
+	for _, value := range values {
+		if len(records[value]) == 1 {
+			warnings = append(warnings, fmt.Sprintf("record %d: unknown lighting value %q, expected one of %v", records[value][0], value, models.LightingLevels))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%d records (first: record %d): unknown lighting value %q, expected one of %v", len(records[value]), records[value][0], value, models.LightingLevels))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,27 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestValidate(t *testing.T) {
+	properties := []models.Property{
+		{Lighting: "high"},
+		{Lighting: ""},
+		{Lighting: "bright"},
+		{Lighting: ""},
+		{Lighting: " Medium "},
+		{Lighting: ""},
+	}
+
+	expected := []string{
+		`3 records (first: record 2): unknown lighting value "", expected one of [low medium high]`,
+		`record 3: unknown lighting value "bright", expected one of [low medium high]`,
+	}
+	if warnings := Validate(properties); !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("expected %q, got %q", expected, warnings)
+	}
+}
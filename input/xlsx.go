@@ -0,0 +1,180 @@
+package input
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// Header rows are searched for within the first rows of the sheet, so title
+// rows or notes above the table are skipped.
+const maxXLSXHeaderRow = 10
+
+func FromXLSXFile(ctx context.Context, filename string, sheet string) ([]models.Property, error) {
+	reader, err := Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return FromXLSX(ctx, reader, sheet)
+}
+
+// FromXLSX reads properties from the given sheet, or the first sheet when
+// sheet is empty. Columns that aren't property fields are read as amenities
+// when every non-empty cell in them is a boolean, and skipped otherwise.
+func FromXLSX(ctx context.Context, r io.Reader, sheet string) ([]models.Property, error) {
+	file, err := excelize.OpenReader(withContext(ctx, r))
+	if err != nil {
+		return nil, fmt.Errorf("error reading XLSX data: %v", err)
+	}
+	defer file.Close()
+
+	if sheet == "" {
+		sheet = file.GetSheetName(0)
+	} else if index, err := file.GetSheetIndex(sheet); err != nil || index < 0 {
+		return nil, fmt.Errorf("sheet %q not found, available sheets: %s", sheet, strings.Join(file.GetSheetList(), ", "))
+	}
+
+	rows, err := file.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("error reading sheet %q: %v", sheet, err)
+	}
+
+	headerRow := -1
+	var columnIndex map[string]int
+	var ammenityColumns map[string]int
+	for i := 0; i < len(rows) && i < maxXLSXHeaderRow; i++ {
+		columnIndex, ammenityColumns = headerColumns(rows[i])
+		if hasColumns(columnIndex, requiredColumns) {
+			headerRow = i
+			break
+		}
+	}
+	if headerRow < 0 {
+		return nil, fmt.Errorf("no header row found in sheet %q, expected columns: %s", sheet, strings.Join(requiredColumns, ", "))
+	}
+
+	ammenityColumns = booleanColumns(rows[headerRow+1:], ammenityColumns)
+
+	var properties []models.Property
+
+	for i, row := range rows[headerRow+1:] {
+		if isBlankRow(row) {
+			continue
+		}
+
+		rowNumber := headerRow + i + 2
+		cell := func(column string) string {
+			return cellAt(row, columnIndex[column])
+		}
+		number := func(column string) (float64, error) {
+			value, err := strconv.ParseFloat(cell(column), 64)
+			if err != nil {
+				return 0, fmt.Errorf("row %d: invalid %s value: %v", rowNumber, column, err)
+			}
+			return value, nil
+		}
+
+		property := models.Property{}
+
+		if property.SquareFootage, err = number("squareFootage"); err != nil {
+			return nil, err
+		}
+		if property.Price, err = number("price"); err != nil {
+			return nil, err
+		}
+		if property.Rooms, err = number("rooms"); err != nil {
+			return nil, err
+		}
+		if property.Bathrooms, err = number("bathrooms"); err != nil {
+			return nil, err
+		}
+		if property.Location[0], err = number("latitude"); err != nil {
+			return nil, err
+		}
+		if property.Location[1], err = number("longitude"); err != nil {
+			return nil, err
+		}
+
+		if _, ok := columnIndex["lighting"]; ok {
+			property.Lighting = cell("lighting")
+		}
+		if _, ok := columnIndex["description"]; ok {
+			property.Description = cell("description")
+		}
+
+		property.Ammenities = map[string]bool{}
+		if _, ok := columnIndex["ammenities"]; ok && cell("ammenities") != "" {
+			if err := json.Unmarshal([]byte(cell("ammenities")), &property.Ammenities); err != nil {
+				return nil, fmt.Errorf("row %d: invalid ammenities JSON: %v", rowNumber, err)
+			}
+		}
+		for name, column := range ammenityColumns {
+			value := cellAt(row, column)
+			if value == "" {
+				continue
+			}
+			property.Ammenities[name], _ = parseXLSXBool(value)
+		}
+
+		properties = append(properties, property)
+	}
+
+	return properties, nil
+}
+
+// booleanColumns returns the columns whose non-empty cells all parse as
+// booleans, so free-text columns like notes aren't read as amenities.
+func booleanColumns(rows [][]string, columns map[string]int) map[string]int {
+	booleans := map[string]int{}
+	for name, column := range columns {
+		boolean := true
+		for _, row := range rows {
+			if value := cellAt(row, column); value != "" {
+				if _, err := parseXLSXBool(value); err != nil {
+					boolean = false
+					break
+				}
+			}
+		}
+		if boolean {
+			booleans[name] = column
+		}
+	}
+	return booleans
+}
+
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func cellAt(row []string, i int) string {
+	if i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+// Boolean cells come back as 1 or 0, typed text as TRUE/FALSE or yes/no.
+func parseXLSXBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "1", "true", "yes", "y":
+		return true, nil
+	case "0", "false", "no", "n":
+		return false, nil
+	default:
+		return false, fmt.Errorf("expected a boolean, got %q", s)
+	}
+}
@@ -0,0 +1,93 @@
+package input
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestFromXLSXFileHeaderDetection(t *testing.T) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	if _, err := file.NewSheet("Listings"); err != nil {
+		t.Fatal(err)
+	}
+	rows := [][]interface{}{
+		{"Listings export"},
+		{},
+		{"Price", "SQFT", "Rooms", "Baths", "Lat", "Long", "Pool", "Source", "Location", "Agent Notes", "Parking"},
+		{300000, 1500, 3, 2, 34.05, -118.24, "yes", "north.csv", "34.05,-118.24", "call after 5pm", "no"},
+		{},
+		{150000, 700, 1, 1, 40.71, -74.0, "", "south.csv", "", "", "street"},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := file.SetSheetRow("Listings", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "in.xlsx")
+	if err := file.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []models.Property{
+		{Price: 300000, SquareFootage: 1500, Rooms: 3, Bathrooms: 2, Location: [2]float64{34.05, -118.24}, Ammenities: map[string]bool{"pool": true}},
+		{Price: 150000, SquareFootage: 700, Rooms: 1, Bathrooms: 1, Location: [2]float64{40.71, -74.0}, Ammenities: map[string]bool{}},
+	}
+	result, err := FromXLSXFile(context.Background(), path, "Listings")
+	if err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+
+	if _, err := FromXLSXFile(context.Background(), path, "Missing"); err == nil {
+		t.Errorf("expected error for missing sheet but got nil")
+	}
+	if _, err := FromXLSXFile(context.Background(), path, ""); err == nil {
+		t.Errorf("expected error for sheet without header but got nil")
+	}
+}
+
+func TestFromXLSXFileCellTypes(t *testing.T) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	sheet := file.GetSheetName(0)
+	rows := [][]interface{}{
+		{"squareFootage", "lighting", "price", "rooms", "bathrooms", "latitude", "longitude", "description", "Garage", "Pool", "source", "score"},
+		{1200, "high", 250000.5, 3, 2, 34.05, -118.24, "Bright loft", false, true, "north.csv", 0.5},
+		{800, "low", 90000, 1, 1, 40.71, -74.0, "Studio", nil, "1"},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := file.SetSheetRow(sheet, cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "in.xlsx")
+	if err := file.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []models.Property{
+		{SquareFootage: 1200, Lighting: "high", Price: 250000.5, Rooms: 3, Bathrooms: 2, Location: [2]float64{34.05, -118.24}, Description: "Bright loft", Ammenities: map[string]bool{"garage": false, "pool": true}},
+		{SquareFootage: 800, Lighting: "low", Price: 90000, Rooms: 1, Bathrooms: 1, Location: [2]float64{40.71, -74.0}, Description: "Studio", Ammenities: map[string]bool{"pool": true}},
+	}
+	result, err := FromXLSXFile(context.Background(), path, "")
+	if err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
@@ -1,172 +1,573 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"text/template"
 
-	"github.com/ramirofarias/prop-filter-cli/filter"
-	"github.com/ramirofarias/prop-filter-cli/input"
-	"github.com/ramirofarias/prop-filter-cli/models"
-	"github.com/ramirofarias/prop-filter-cli/output"
 	"github.com/ramirofarias/prop-filter-cli/parser"
+	"github.com/ramirofarias/prop-filter-cli/propfilter"
 	"github.com/urfave/cli/v2"
 )
 
+var filterFlags = []cli.Flag{
+	&cli.StringSliceFlag{
+		Name:  "input",
+		Usage: `Path or glob of JSON, CSV, GeoJSON, XLSX, SQLite or Parquet input files, can be repeated. Example: "data/2026-10-*/*.csv"`,
+	},
+	&cli.StringSliceFlag{
+		Name:  "input-dir",
+		Usage: "Directory to read input files from, can be repeated. Without --include, only files in a supported format are read",
+	},
+	&cli.BoolFlag{
+		Name:  "recursive",
+		Usage: "Also read files in subdirectories of --input-dir",
+	},
+	&cli.StringFlag{
+		Name:  "include",
+		Usage: `Only read files in --input-dir matching these globs (comma-separated). Example: "*.json,*.csv"`,
+	},
+	&cli.StringFlag{
+		Name:  "exclude",
+		Usage: `Skip files in --input-dir matching these globs (comma-separated). Example: "*_draft*"`,
+	},
+	&cli.StringFlag{
+		Name:  "sheet",
+		Usage: "XLSX sheet to read from --input (default: first sheet) or write to --output (default: Properties)",
+	},
+	&cli.StringFlag{
+		Name:  "sqlite-table",
+		Usage: "SQLite table to read from --input (default: properties)",
+	},
+	&cli.StringFlag{
+		Name:  "sqlite-query",
+		Usage: `SQLite query to read from --input instead of a table. Example: "SELECT * FROM properties WHERE price < 300000"`,
+	},
+	&cli.StringFlag{
+		Name:  "index",
+		Usage: `Path to an index file built with the "index" command, used instead of --input`,
+	},
+	&cli.StringFlag{
+		Name:  "sqft",
+		Usage: `Filter by square footage. Examples: "gt 1500", "eq 1500", "lt 1500", "lte 1500", "in 1500,2000"`,
+	},
+	&cli.StringFlag{
+		Name:  "bathrooms",
+		Usage: `Filter by amount of bathrooms. Examples: "gt 1", "eq 1", "lt 3", "lte 3", "gte 3", "in 1,3"`,
+	},
+	&cli.StringFlag{
+		Name:  "rooms",
+		Usage: `Filter by amount of rooms. Examples: "gt 1", "eq 1", "lt 3", "lte 3", "gte 3", "in 1,3"`,
+	},
+	&cli.StringFlag{
+		Name:  "distance",
+		Usage: `Filter by distance in km to lat and long flags. Examples: "gt 100", "eq 100", "lt 100", "lte 100", "gte 100", "in 150,200"`,
+	},
+	&cli.StringFlag{
+		Name:  "price",
+		Usage: `Filter by price. Examples: "gt 1000", "eq 1000", "lt 1000", "lte 1000", "gte 1000"`,
+	},
+	&cli.Float64Flag{
+		Name:  "lat",
+		Value: -999999,
+		Usage: `Latitude to compare distance`,
+	},
+	&cli.Float64Flag{
+		Name:  "long",
+		Value: -999999,
+		Usage: `Longitude to compare distance`,
+	},
+	&cli.Float64Flag{
+		Name:  "epsilon",
+		Usage: `Tolerance for eq, ne and oneof comparisons without an explicit one. Example: 0.01`,
+	},
+	&cli.StringFlag{
+		Name:  "lighting",
+		Usage: `Lighting type, ordered low < medium < high. Examples: "high", "low,medium", "gte medium"`,
+	},
+	&cli.StringFlag{
+		Name:  "keywords",
+		Usage: `Keywords to search in description (comma-separated). Example: "spacious,big"`,
+	},
+	&cli.StringFlag{
+		Name:    "ammenities",
+		Aliases: []string{"amenities"},
+		Usage:   `Required amenities (comma-separated), prefix with ! to exclude. Example: "garage,yard,!pool"`,
+	},
+	&cli.StringFlag{
+		Name:  "amenities-any",
+		Usage: `Require at least one of these amenities (comma-separated). Example: "pool,jacuzzi"`,
+	},
+	&cli.StringFlag{
+		Name:  "amenities-none",
+		Usage: `Exclude properties with any of these amenities (comma-separated). Example: "hoa"`,
+	},
+	&cli.StringFlag{
+		Name:  "amenities-unknown",
+		Value: propfilter.UnknownAmmenitiesAsFalse,
+		Usage: `How to handle properties missing a filtered amenity. Possible values: 'treat-as-false' | 'exclude'`,
+	},
+	&cli.StringFlag{
+		Name:  "source",
+		Usage: `Only keep records from input files matching these globs (comma-separated), matched against the path or file name. Example: "*north*,*south*"`,
+	},
+	&cli.StringFlag{
+		Name:  "search",
+		Usage: `Rank properties by description relevance (BM25). Example: "quiet family home near schools"`,
+	},
+	&cli.StringFlag{
+		Name:  "output",
+		Usage: `Output file path in .csv, .json, .ndjson, .geojson, .kml, .xlsx, .sqlite, .db, .parquet, .md or .html. Examples: "file.csv", "file.json", "file.ndjson", "file.geojson", "file.kml", "file.xlsx", "file.sqlite", "file.parquet", "report.md", "report.html"`,
+	},
+	&cli.StringFlag{
+		Name:  "compress",
+		Usage: `Compress the --output file. Implied by a .gz or .zst extension. Possible values: 'gzip' | 'zstd'`,
+	},
+	&cli.BoolFlag{
+		Name:  "force",
+		Usage: "Replace the --output file if it already exists",
+	},
+	&cli.BoolFlag{
+		Name:  "append",
+		Usage: "Add to an existing .csv or .ndjson --output file, whose header must match the fields written",
+	},
+	&cli.StringFlag{
+		Name:  "output-format",
+		Value: "json",
+		Usage: `Format printed to stdout when --output isn't set. Possible values: 'json' | 'ndjson' | 'table'`,
+	},
+	&cli.StringFlag{
+		Name:  "fields",
+		Usage: `Output fields in order (comma-separated), optionally renamed with "field:Name". Example: "price:Price USD,sqft,location,amenities.pool"`,
+	},
+	&cli.StringFlag{
+		Name:  "columns",
+		Usage: `Table columns (comma-separated). Possible values: 'price' | 'sqft' | 'rooms' | 'bathrooms' | 'lighting' | 'location' | 'amenities' | 'description' | 'score'`,
+	},
+	&cli.StringFlag{
+		Name:  "template",
+		Usage: `Path to a Go text/template file used to render the results instead of --output-format or the --output extension`,
+	},
+	&cli.StringFlag{
+		Name:  "template-inline",
+		Usage: `Inline Go text/template used like --template. Example: '{{range .}}{{formatMoney .Price}}{{"\n"}}{{end}}'`,
+	},
+	&cli.StringFlag{
+		Name:  "kml-name",
+		Usage: `Fields used to name KML placemarks (comma-separated). Possible values: 'price' | 'sqft' | 'rooms' | 'bathrooms' | 'lighting' | 'description'`,
+		Value: "price,rooms",
+	},
+	&cli.StringFlag{
+		Name:  "kml-style",
+		Usage: `Color KML placemarks by band. Possible values: 'lighting' | 'price'`,
+	},
+	&cli.StringFlag{
+		Name:  "amenities-key",
+		Value: propfilter.AmmenitiesKey,
+		Usage: `Spelling of the amenities key in JSON and CSV output. Possible values: 'ammenities' | 'amenities'`,
+	},
+	&cli.DurationFlag{
+		Name:  "timeout",
+		Usage: `Stop and fail if filtering takes longer than this. Example: "30s", "5m"`,
+	},
+	&cli.IntFlag{
+		Name:  "workers",
+		Usage: "Number of input files read and chunks of properties filtered at once (default: number of CPUs)",
+	},
+}
+
+var criteriaFlags = []string{
+	"sqft", "bathrooms", "rooms", "distance", "price", "lat", "long", "epsilon", "lighting",
+	"keywords", "ammenities", "amenities-any", "amenities-none", "amenities-unknown", "source", "search",
+}
+
 func main() {
 	app := &cli.App{
 		Name:  "prop-filter-cli",
-		Usage: "Filter property data from JSON or CSV files",
-		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:     "input",
-				Usage:    "Path to JSON or CSV input file",
-				Required: true,
-			},
-			&cli.StringFlag{
-				Name:  "sqft",
-				Usage: `Filter by square footage. Examples: "gt 1500", "eq 1500", "lt 1500", "lte 1500", "in 1500,2000"`,
-			},
-			&cli.StringFlag{
-				Name:  "bathrooms",
-				Usage: `Filter by amount of bathrooms. Examples: "gt 1", "eq 1", "lt 3", "lte 3", "gte 3", "in 1,3"`,
-			},
-			&cli.StringFlag{
-				Name:  "rooms",
-				Usage: `Filter by amount of rooms. Examples: "gt 1", "eq 1", "lt 3", "lte 3", "gte 3", "in 1,3"`,
-			},
-			&cli.StringFlag{
-				Name:  "distance",
-				Usage: `Filter by distance in km to lat and long flags. Examples: "gt 100", "eq 100", "lt 100", "lte 100", "gte 100", "in 150,200"`,
-			},
-			&cli.StringFlag{
-				Name:  "price",
-				Usage: `Filter by price. Examples: "gt 1000", "eq 1000", "lt 1000", "lte 1000", "gte 1000"`,
-			},
-			&cli.Float64Flag{
-				Name:  "lat",
-				Value: -999999,
-				Usage: `Latitude to compare distance`,
-			},
-			&cli.Float64Flag{
-				Name:  "long",
-				Value: -999999,
-				Usage: `Longitude to compare distance`,
-			},
-			&cli.StringFlag{
-				Name:  "lighting",
-				Usage: `Lighting type. Possible values: 'low' | 'medium' | 'high'`,
+		Usage: "Filter property data from JSON, CSV, GeoJSON, XLSX, SQLite or Parquet files",
+		Flags: filterFlags,
+		Commands: []*cli.Command{
+			{
+				Name:   "filter",
+				Usage:  "Filter properties from an input or index file (default command)",
+				Flags:  filterFlags,
+				Action: withTimeout(filterAction),
 			},
-			&cli.StringFlag{
-				Name:  "keywords",
-				Usage: `Keywords to search in description (comma-separated). Example: "spacious,big"`,
-			},
-			&cli.StringFlag{
-				Name:  "ammenities",
-				Usage: `Required amenities (comma-separated). Example: "garage,yard"`,
-			},
-			&cli.StringFlag{
-				Name:  "output",
-				Usage: `Output file path in .csv or .json. Examples: "file.csv", "file.json"`,
+			{
+				Name:  "index",
+				Usage: "Parse an input file once into a binary index file for fast filtering",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "input",
+						Usage:    "Path to JSON, CSV, GeoJSON, XLSX, SQLite or Parquet input file",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "sheet",
+						Usage: "XLSX sheet to read (default: first sheet)",
+					},
+					&cli.StringFlag{
+						Name:  "sqlite-table",
+						Usage: "SQLite table to read (default: properties)",
+					},
+					&cli.StringFlag{
+						Name:  "sqlite-query",
+						Usage: "SQLite query to read instead of a table",
+					},
+					&cli.StringFlag{
+						Name:     "output",
+						Usage:    `Index file path. Example: "properties.idx"`,
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Replace the --output index file if it already exists",
+					},
+					&cli.DurationFlag{
+						Name:  "timeout",
+						Usage: `Stop and fail if indexing takes longer than this. Example: "5m"`,
+					},
+				},
+				Action: withTimeout(indexAction),
 			},
 		},
 		EnableBashCompletion: true,
-		Action: func(c *cli.Context) error {
-			inputPath := c.String("input")
-			var properties []models.Property
+		Action:               withTimeout(filterAction),
+	}
 
-			fileType, err := parser.ParseFiletype(inputPath)
-			if err != nil {
-				return fmt.Errorf("error parsing input file type: %v", err)
-			}
+	// The first SIGINT or SIGTERM cancels the run so partial output can be
+	// cleaned up, a second one kills it.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
 
-			switch fileType {
-			case "json":
-				properties, err = input.FromJSONFile(inputPath)
-			case "csv":
-				properties, err = input.FromCSVFile(inputPath)
-			}
+	if err := app.RunContext(ctx, os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "error running app: %v\n", err)
+		os.Exit(1)
+	}
+}
 
-			if err != nil {
-				return fmt.Errorf("error parsing input file: %v", err)
-			}
+// withTimeout runs action with a context that's cancelled on --timeout, and
+// reports cancellation instead of whichever error it caused.
+func withTimeout(action func(ctx context.Context, c *cli.Context) error) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		ctx := c.Context
+		timeout := c.Duration("timeout")
+		if timeout < 0 {
+			return fmt.Errorf("timeout can't be negative")
+		}
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
 
-			var filters filter.Filter
-			if sqft := c.String("sqft"); sqft != "" {
-				filters.SquareFootage, err = parser.ParseComparison(sqft)
-				if err != nil {
-					return fmt.Errorf("error parsing sqft filter: %v", err)
-				}
-			}
-			if bathrooms := c.String("bathrooms"); bathrooms != "" {
-				filters.Bathrooms, err = parser.ParseComparison(bathrooms)
-				if err != nil {
-					return fmt.Errorf("error parsing bathrooms filter: %v", err)
-				}
-			}
-			if rooms := c.String("rooms"); rooms != "" {
-				filters.Rooms, err = parser.ParseComparison(rooms)
-				if err != nil {
-					return fmt.Errorf("error parsing rooms filter: %v", err)
-				}
-			}
-			filters.Lat = c.Float64("lat")
-			filters.Long = c.Float64("long")
-			if distance := c.String("distance"); distance != "" {
-				if filters.Lat == -999999 || filters.Long == -999999 {
-					return fmt.Errorf("lat and long flags are required when using distance filter")
-				}
-				filters.Distance, err = parser.ParseComparison(distance)
-				if err != nil {
-					return fmt.Errorf("error parsing distance filter: %v", err)
-				}
-			}
-			if price := c.String("price"); price != "" {
-				filters.Price, err = parser.ParseComparison(price)
-				if err != nil {
-					return fmt.Errorf("error parsing price filter: %v", err)
-				}
+		err := action(ctx, c)
+		if err != nil {
+			switch ctx.Err() {
+			case context.DeadlineExceeded:
+				return fmt.Errorf("timed out after %s", timeout)
+			case context.Canceled:
+				return fmt.Errorf("interrupted")
 			}
+		}
+		return err
+	}
+}
 
-			filters.Lighting = c.String("lighting")
-			if keywords := c.String("keywords"); keywords != "" {
-				filters.Keywords = parser.ParseText(keywords)
-			}
-			if ammenities := c.String("ammenities"); ammenities != "" {
-				filters.Ammenities = parser.ParseText(ammenities)
-			}
+func filterAction(ctx context.Context, c *cli.Context) error {
+	if err := checkOutput(c); err != nil {
+		return err
+	}
+
+	var err error
+	var filters propfilter.Filter
+	if sqft := c.String("sqft"); sqft != "" {
+		filters.SquareFootage, err = parser.ParseComparison(sqft)
+		if err != nil {
+			return fmt.Errorf("error parsing sqft filter: %v", err)
+		}
+	}
+	if bathrooms := c.String("bathrooms"); bathrooms != "" {
+		filters.Bathrooms, err = parser.ParseComparison(bathrooms)
+		if err != nil {
+			return fmt.Errorf("error parsing bathrooms filter: %v", err)
+		}
+	}
+	if rooms := c.String("rooms"); rooms != "" {
+		filters.Rooms, err = parser.ParseComparison(rooms)
+		if err != nil {
+			return fmt.Errorf("error parsing rooms filter: %v", err)
+		}
+	}
+	filters.Epsilon = c.Float64("epsilon")
+	if filters.Epsilon < 0 {
+		return fmt.Errorf("epsilon can't be negative")
+	}
+	workers := c.Int("workers")
+	if workers < 0 {
+		return fmt.Errorf("workers can't be negative")
+	}
+	filters.Lat = c.Float64("lat")
+	filters.Long = c.Float64("long")
+	if distance := c.String("distance"); distance != "" {
+		if filters.Lat == -999999 || filters.Long == -999999 {
+			return fmt.Errorf("lat and long flags are required when using distance filter")
+		}
+		filters.Distance, err = parser.ParseComparison(distance)
+		if err != nil {
+			return fmt.Errorf("error parsing distance filter: %v", err)
+		}
+	}
+	if price := c.String("price"); price != "" {
+		filters.Price, err = parser.ParseComparison(price)
+		if err != nil {
+			return fmt.Errorf("error parsing price filter: %v", err)
+		}
+	}
+
+	if lighting := c.String("lighting"); lighting != "" {
+		filters.Lighting, err = parser.ParseLighting(lighting)
+		if err != nil {
+			return fmt.Errorf("error parsing lighting filter: %v", err)
+		}
+	}
+	if keywords := c.String("keywords"); keywords != "" {
+		filters.Keywords = parser.ParseText(keywords)
+	}
+	if ammenities := c.String("ammenities"); ammenities != "" {
+		filters.Ammenities, filters.AmmenitiesNone = parser.ParseAmmenities(ammenities)
+	}
+	if ammenitiesAny := c.String("amenities-any"); ammenitiesAny != "" {
+		filters.AmmenitiesAny = parser.ParseText(ammenitiesAny)
+	}
+	if ammenitiesNone := c.String("amenities-none"); ammenitiesNone != "" {
+		filters.AmmenitiesNone = append(filters.AmmenitiesNone, parser.ParseText(ammenitiesNone)...)
+	}
+	if sources := c.String("source"); sources != "" {
+		filters.Sources = parser.ParsePatterns(sources)
+	}
+	filters.UnknownAmmenities = c.String("amenities-unknown")
+	if filters.UnknownAmmenities != propfilter.UnknownAmmenitiesAsFalse && filters.UnknownAmmenities != propfilter.UnknownAmmenitiesExclude {
+		return fmt.Errorf("invalid amenities-unknown value: %s", filters.UnknownAmmenities)
+	}
+
+	var fields []propfilter.Field
+	if fieldList := c.String("fields"); fieldList != "" {
+		fields, err = propfilter.ParseFields(fieldList)
+		if err != nil {
+			return fmt.Errorf("error parsing fields: %v", err)
+		}
+	}
+
+	outputPath := c.String("output")
+	outputOptions := propfilter.WriteOptions{
+		AmmenitiesKey: c.String("amenities-key"),
+		KMLNameFields: parser.ParseText(c.String("kml-name")),
+		KMLStyle:      c.String("kml-style"),
+		Sheet:         c.String("sheet"),
+		Fields:        fields,
+		Overwrite:     c.Bool("force"),
+		Append:        c.Bool("append"),
+		Compression:   c.String("compress"),
+	}
+	if columns := c.String("columns"); columns != "" {
+		outputOptions.Columns = parser.ParseText(columns)
+	}
+	for _, name := range criteriaFlags {
+		if c.IsSet(name) {
+			outputOptions.Criteria = append(outputOptions.Criteria, fmt.Sprintf("--%s %s", name, c.Value(name)))
+		}
+	}
+	if err := propfilter.CheckWriteOptions(outputOptions); err != nil {
+		return err
+	}
+	if outputPath == "" && outputOptions.Compression != "" {
+		return fmt.Errorf("--compress requires --output")
+	}
+	if compression := parser.ParseCompression(outputPath); compression != "" {
+		if outputOptions.Compression != "" && outputOptions.Compression != compression {
+			return fmt.Errorf("--compress %s doesn't match the output file extension", outputOptions.Compression)
+		}
+		outputOptions.Compression = compression
+	}
 
-			filteredProperties := filter.FilterProperties(properties, filters)
-
-			outputPath := c.String("output")
-			if outputPath != "" {
-				fileType, err := parser.ParseFiletype(outputPath)
-				if err != nil {
-					return fmt.Errorf("error parsing output file type: %v", err)
-				}
-
-				switch fileType {
-				case "json":
-					if err := output.ToJSONFile(filteredProperties, outputPath); err != nil {
-						return fmt.Errorf("error writing JSON output file: %v", err)
-					}
-				case "csv":
-					if err := output.ToCSVFile(filteredProperties, outputPath); err != nil {
-						return fmt.Errorf("error writing CSV output file: %v", err)
-					}
-				}
-
-			} else {
-				if err := output.ToJSONStdOut(filteredProperties); err != nil {
-					return fmt.Errorf("error printing data to stdout: %v", err)
-				}
+	templateText, err := readTemplate(c)
+	if err != nil {
+		return err
+	}
+	outputFormat := c.String("output-format")
+	if outputPath == "" && templateText == "" {
+		switch outputFormat {
+		case "json", "ndjson", "table":
+		default:
+			return fmt.Errorf("invalid output-format value: %s", outputFormat)
+		}
+	}
+	var tmpl *template.Template
+	if templateText != "" {
+		tmpl, err = propfilter.ParseTemplate("output", templateText)
+		if err != nil {
+			return fmt.Errorf("error parsing template: %v", err)
+		}
+	}
+
+	query := propfilter.Query{Filter: filters, Search: c.String("search"), Workers: workers}
+	var properties []propfilter.Property
+	var warnings []propfilter.Warning
+
+	hasInput := len(c.StringSlice("input")) > 0 || len(c.StringSlice("input-dir")) > 0
+	indexPath := c.String("index")
+	switch {
+	case hasInput && indexPath != "":
+		return fmt.Errorf("--input and --index can't be used together")
+	case indexPath != "":
+		properties, warnings, err = propfilter.ApplyIndex(ctx, indexPath, query)
+	case hasInput:
+		var inputPaths []string
+		inputPaths, err = propfilter.ListFiles(propfilter.ListOptions{
+			Paths:     c.StringSlice("input"),
+			Dirs:      c.StringSlice("input-dir"),
+			Recursive: c.Bool("recursive"),
+			Include:   parser.ParsePatterns(c.String("include")),
+			Exclude:   parser.ParsePatterns(c.String("exclude")),
+		})
+		if err != nil {
+			return err
+		}
+
+		readOptions := readOptions(c)
+		readOptions.Filter = filters
+		// Only the fields that are filtered on and written are read, unless
+		// the output writes every field.
+		if len(fields) > 0 && tmpl == nil {
+			switch propfilter.Format(outputPath) {
+			case "", "json", "ndjson", "csv", "xlsx":
+				readOptions.Columns = propfilter.Columns(query, fields)
 			}
+		}
+		properties, warnings, err = propfilter.ReadFiles(ctx, inputPaths, readOptions, workers)
+		if err == nil {
+			properties, err = propfilter.Apply(ctx, properties, query)
+		}
+	default:
+		return fmt.Errorf("either --input or --index is required")
+	}
+	printWarnings(warnings)
+	if err != nil {
+		return err
+	}
 
-			return nil
-		},
+	switch {
+	case tmpl != nil && outputPath != "":
+		if err := propfilter.WriteTemplateFile(ctx, outputPath, properties, tmpl, outputOptions); err != nil {
+			return fmt.Errorf("error writing template output file: %v", err)
+		}
+	case tmpl != nil:
+		if err := propfilter.WriteTemplate(ctx, os.Stdout, properties, tmpl); err != nil {
+			return fmt.Errorf("error printing template to stdout: %v", err)
+		}
+	case outputPath != "":
+		if err := propfilter.WriteFile(ctx, outputPath, properties, outputOptions); err != nil {
+			return fmt.Errorf("error writing output file: %v", err)
+		}
+	case outputFormat == "table":
+		if err := propfilter.PrintTable(ctx, properties, outputOptions); err != nil {
+			return fmt.Errorf("error printing table to stdout: %v", err)
+		}
+	default:
+		if err := propfilter.Write(ctx, os.Stdout, outputFormat, properties, outputOptions); err != nil {
+			return fmt.Errorf("error printing data to stdout: %v", err)
+		}
 	}
 
-	if err := app.Run(os.Args); err != nil {
-		fmt.Fprintf(os.Stderr, "error running app: %v\n", err)
-		os.Exit(1)
+	return nil
+}
+
+// checkOutput validates --force and --append, and refuses an existing --output
+// file without them before any input is read.
+func checkOutput(c *cli.Context) error {
+	outputPath := c.String("output")
+	if c.Bool("force") && c.Bool("append") {
+		return fmt.Errorf("--force and --append can't be used together")
+	}
+	if c.Bool("append") {
+		if outputPath == "" {
+			return fmt.Errorf("--append requires --output")
+		}
+		if c.String("template") != "" || c.String("template-inline") != "" {
+			return fmt.Errorf("--append can't be used with a template")
+		}
+		if format := propfilter.Format(outputPath); format != "csv" && format != "ndjson" {
+			return fmt.Errorf("--append is only supported for .csv and .ndjson output, got %q", format)
+		}
+		if parser.ParseCompression(outputPath) != "" || c.String("compress") != "" {
+			return fmt.Errorf("--append can't be used with compressed output")
+		}
+	}
+
+	if outputPath == "" || c.Bool("force") || c.Bool("append") {
+		return nil
+	}
+	// SQLite output upserts into an existing database.
+	if format := propfilter.Format(outputPath); format == "sqlite" || format == "db" {
+		return nil
+	}
+	return checkExists(outputPath, "use --force to replace it or --append to add to it")
+}
+
+func checkExists(path string, hint string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("output file %s already exists, %s", path, hint)
+	}
+	return nil
+}
+
+func indexAction(ctx context.Context, c *cli.Context) error {
+	if !c.Bool("force") {
+		if err := checkExists(c.String("output"), "use --force to replace it"); err != nil {
+			return err
+		}
+	}
+
+	warnings, err := propfilter.BuildIndex(ctx, c.String("input"), readOptions(c), c.String("output"), c.Bool("force"))
+	printWarnings(warnings)
+	return err
+}
+
+func readOptions(c *cli.Context) propfilter.ReadOptions {
+	return propfilter.ReadOptions{
+		Sheet:       c.String("sheet"),
+		SQLiteTable: c.String("sqlite-table"),
+		SQLiteQuery: c.String("sqlite-query"),
+	}
+}
+
+func printWarnings(warnings []propfilter.Warning) {
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+}
+
+func readTemplate(c *cli.Context) (string, error) {
+	templatePath := c.String("template")
+	templateInline := c.String("template-inline")
+
+	switch {
+	case templatePath != "" && templateInline != "":
+		return "", fmt.Errorf("--template and --template-inline can't be used together")
+	case templatePath != "":
+		content, err := os.ReadFile(templatePath)
+		if err != nil {
+			return "", fmt.Errorf("error reading template file: %v", err)
+		}
+		return string(content), nil
+	default:
+		return templateInline, nil
 	}
 }
@@ -0,0 +1,14 @@
+package models
+
+import "strings"
+
+var LightingLevels = []string{"low", "medium", "high"}
+
+func LightingRank(lighting string) (int, bool) {
+	for i, level := range LightingLevels {
+		if strings.EqualFold(strings.TrimSpace(lighting), level) {
+			return i, true
+		}
+	}
+	return -1, false
+}
@@ -0,0 +1,47 @@
+package models
+
+// ParquetProperty is the Parquet row layout of a Property. The location is
+// split into latitude and longitude columns so each gets its own statistics.
+type ParquetProperty struct {
+	SquareFootage float64         `parquet:"squareFootage"`
+	Lighting      string          `parquet:"lighting"`
+	Price         float64         `parquet:"price"`
+	Rooms         float64         `parquet:"rooms"`
+	Bathrooms     float64         `parquet:"bathrooms"`
+	Latitude      float64         `parquet:"latitude"`
+	Longitude     float64         `parquet:"longitude"`
+	Description   string          `parquet:"description"`
+	Ammenities    map[string]bool `parquet:"ammenities"`
+}
+
+func NewParquetProperty(p Property) ParquetProperty {
+	return ParquetProperty{
+		SquareFootage: p.SquareFootage,
+		Lighting:      p.Lighting,
+		Price:         p.Price,
+		Rooms:         p.Rooms,
+		Bathrooms:     p.Bathrooms,
+		Latitude:      p.Location[0],
+		Longitude:     p.Location[1],
+		Description:   p.Description,
+		Ammenities:    p.Ammenities,
+	}
+}
+
+func (p ParquetProperty) Property() Property {
+	ammenities := p.Ammenities
+	if ammenities == nil {
+		ammenities = map[string]bool{}
+	}
+
+	return Property{
+		SquareFootage: p.SquareFootage,
+		Lighting:      p.Lighting,
+		Price:         p.Price,
+		Rooms:         p.Rooms,
+		Bathrooms:     p.Bathrooms,
+		Location:      [2]float64{p.Latitude, p.Longitude},
+		Description:   p.Description,
+		Ammenities:    ammenities,
+	}
+}
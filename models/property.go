@@ -1,5 +1,16 @@
 package models
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	AmmenitiesKey = "ammenities"
+	AmenitiesKey  = "amenities"
+)
+
 type Property struct {
 	SquareFootage float64         `json:"squareFootage"`
 	Lighting      string          `json:"lighting"`
@@ -9,4 +20,30 @@ type Property struct {
 	Location      [2]float64      `json:"location"`
 	Description   string          `json:"description"`
 	Ammenities    map[string]bool `json:"ammenities"`
+	Score         *float64        `json:"score,omitempty"`
+	Source        string          `json:"source,omitempty"`
+}
+
+func (p *Property) UnmarshalJSON(data []byte) error {
+	type property Property
+	var record struct {
+		property
+		Amenities map[string]bool `json:"amenities"`
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&record); err != nil {
+		return err
+	}
+
+	if record.Ammenities != nil && record.Amenities != nil {
+		return fmt.Errorf("property has both %q and %q keys", AmmenitiesKey, AmenitiesKey)
+	}
+	if record.Amenities != nil {
+		record.Ammenities = record.Amenities
+	}
+
+	*p = Property(record.property)
+	return nil
 }
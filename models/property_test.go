@@ -0,0 +1,56 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPropertyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  map[string]bool
+		expectErr bool
+	}{
+		{
+			name:     "Legacy spelling",
+			input:    `{"ammenities": {"pool": true}}`,
+			expected: map[string]bool{"pool": true},
+		},
+		{
+			name:     "Correct spelling",
+			input:    `{"amenities": {"pool": false}}`,
+			expected: map[string]bool{"pool": false},
+		},
+		{
+			name:      "Both spellings",
+			input:     `{"ammenities": {"pool": true}, "amenities": {"pool": true}}`,
+			expectErr: true,
+		},
+		{
+			name:      "Unknown field",
+			input:     `{"garden": true}`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var property Property
+			err := json.Unmarshal([]byte(tt.input), &property)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("did not expect error but got: %v", err)
+				}
+				if !reflect.DeepEqual(property.Ammenities, tt.expected) {
+					t.Errorf("expected %v, got %v", tt.expected, property.Ammenities)
+				}
+			}
+		})
+	}
+}
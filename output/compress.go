@@ -0,0 +1,55 @@
+package output
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+type compressedFile struct {
+	io.WriteCloser
+	file   *os.File
+	closed bool
+}
+
+// Close flushes the compressor before closing the file. It's safe to call
+// more than once.
+func (f *compressedFile) Close() error {
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+
+	err := f.WriteCloser.Close()
+	if closeErr := f.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// compress wraps file so what's written is compressed according to
+// options.Compression.
+func compress(file *os.File, options Options) (io.WriteCloser, error) {
+	switch options.Compression {
+	case CompressionGzip:
+		return &compressedFile{WriteCloser: gzip.NewWriter(file), file: file}, nil
+	case CompressionZstd:
+		encoder, err := zstd.NewWriter(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not create zstd writer: %v", err)
+		}
+		return &compressedFile{WriteCloser: encoder, file: file}, nil
+	case "":
+		return file, nil
+	default:
+		return nil, fmt.Errorf("invalid compression: %s", options.Compression)
+	}
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/input"
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestCompressedRoundTrip(t *testing.T) {
+	data := []models.Property{
+		{SquareFootage: 1200, Lighting: "high", Price: 250000, Rooms: 3, Bathrooms: 2, Location: [2]float64{34.05, -118.24}, Description: "Bright loft", Ammenities: map[string]bool{"pool": true}},
+	}
+
+	tests := []struct {
+		path        string
+		compression string
+		magic       []byte
+		write       func(context.Context, []models.Property, string, Options) error
+		read        func(context.Context, string) ([]models.Property, error)
+	}{
+		{"out.csv.gz", CompressionGzip, []byte{0x1f, 0x8b}, ToCSVFile, input.FromCSVFile},
+		{"out.json.zst", CompressionZstd, []byte{0x28, 0xb5, 0x2f, 0xfd}, ToJSONFile, input.FromJSONFile},
+		// Compression is detected from the content, whatever the extension.
+		{"out.json", CompressionGzip, []byte{0x1f, 0x8b}, ToJSONFile, input.FromJSONFile},
+		{"out.csv", "", []byte("squareFootage"), ToCSVFile, input.FromCSVFile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.path)
+			if err := tt.write(context.Background(), data, path, Options{Compression: tt.compression}); err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+
+			raw, err := readPrefix(path, len(tt.magic))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(raw, tt.magic) {
+				t.Errorf("expected file to start with %x, got %x", tt.magic, raw)
+			}
+
+			result, err := tt.read(context.Background(), path)
+			if err != nil {
+				t.Fatalf("did not expect error reading back but got: %v", err)
+			}
+			if !reflect.DeepEqual(result, data) {
+				t.Errorf("expected %v, got %v", data, result)
+			}
+		})
+	}
+
+	if err := ToJSONFile(context.Background(), data, filepath.Join(t.TempDir(), "out.json"), Options{Compression: "brotli"}); err == nil {
+		t.Errorf("expected error for unknown compression but got nil")
+	}
+}
+
+func readPrefix(path string, n int) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	prefix := make([]byte, n)
+	_, err = io.ReadFull(file, prefix)
+	return prefix, err
+}
@@ -0,0 +1,24 @@
+package output
+
+import (
+	"context"
+	"io"
+)
+
+// contextWriter stops writing once its context is done, so encoders writing
+// to it return promptly.
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (w contextWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return w.w.Write(p)
+}
+
+func withContext(ctx context.Context, w io.Writer) io.Writer {
+	return contextWriter{ctx, w}
+}
@@ -1,52 +1,122 @@
 package output
 
 import (
+	"context"
 	"encoding/csv"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/ramirofarias/prop-filter-cli/models"
 )
 
-func ToCSVFile(data []models.Property, path string) error {
-	file, err := os.Create(path)
+func ToCSVFile(ctx context.Context, data []models.Property, path string, options Options) error {
+	if options.Append {
+		return appendCSVFile(ctx, data, path, options)
+	}
+	return toFile(ctx, path, data, options, encodeCSV)
+}
+
+func WriteCSV(ctx context.Context, w io.Writer, data []models.Property, options Options) error {
+	return toWriter(ctx, w, data, options, encodeCSV)
+}
+
+func encodeCSV(data []models.Property, options Options) (func(io.Writer) error, error) {
+	fields, err := resolveFields(data, options, defaultCSVFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer) error {
+		return writeCSV(w, data, fields, true)
+	}, nil
+}
+
+// appendCSVFile adds rows to the CSV file at path, after checking that its
+// header matches. A missing or empty file is written with a header.
+func appendCSVFile(ctx context.Context, data []models.Property, path string, options Options) error {
+	fields, err := resolveFields(data, options, defaultCSVFields)
+	if err != nil {
+		return err
+	}
+
+	existing, err := readCSVHeader(path)
 	if err != nil {
-		return fmt.Errorf("error creating file: %v", err)
+		return err
+	}
+	if existing == nil {
+		options.Append = false
+		options.Overwrite = true
+		return WriteFile(ctx, path, options, func(w io.Writer) error {
+			return writeCSV(w, data, fields, true)
+		})
+	}
+
+	header := csvHeader(fields)
+	if strings.Join(existing, ",") != strings.Join(header, ",") {
+		return fmt.Errorf("can't append to %s: its header is %q, expected %q", path, strings.Join(existing, ","), strings.Join(header, ","))
+	}
+
+	return appendFile(ctx, path, options, func(w io.Writer) error {
+		return writeCSV(w, data, fields, false)
+	})
+}
+
+// readCSVHeader returns the header of the CSV file at path, or nil if it
+// doesn't exist or is empty.
+func readCSVHeader(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open file: %v", err)
 	}
 	defer file.Close()
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	header, err := csv.NewReader(file).Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read CSV header of %s: %v", path, err)
+	}
+	return header, nil
+}
 
-	header := []string{
-		"squareFootage", "lighting", "price", "rooms", "bathrooms", "latitude", "longitude", "description", "ammenities",
+func csvHeader(fields []resolvedField) []string {
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = field.label
 	}
+	return header
+}
+
+func writeCSV(w io.Writer, data []models.Property, fields []resolvedField, header bool) error {
+	writer := csv.NewWriter(w)
 
-	if err := writer.Write(header); err != nil {
-		return fmt.Errorf("error writing CSV header: %v", err)
+	if header {
+		if err := writer.Write(csvHeader(fields)); err != nil {
+			return fmt.Errorf("error writing CSV header: %v", err)
+		}
 	}
 
 	for _, property := range data {
-		var row []string
-		row = append(row, fmt.Sprintf("%d", int(property.SquareFootage)))
-		row = append(row, property.Lighting)
-		row = append(row, fmt.Sprintf("%.2f", property.Price))
-		row = append(row, fmt.Sprintf("%d", int(property.Rooms)))
-		row = append(row, fmt.Sprintf("%d", int(property.Bathrooms)))
-		row = append(row, fmt.Sprintf("%.6f", property.Location[0]))
-		row = append(row, fmt.Sprintf("%.6f", property.Location[1]))
-		row = append(row, property.Description)
-		ammenitiesJSON, err := json.Marshal(property.Ammenities)
-		if err != nil {
-			return fmt.Errorf("error marshalling amenities to JSON: %v", err)
+		row := make([]string, len(fields))
+		for i, field := range fields {
+			row[i] = field.text(property)
 		}
-		row = append(row, string(ammenitiesJSON))
 
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("error writing CSV row: %v", err)
 		}
 	}
 
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing CSV data: %v", err)
+	}
+
 	return nil
 }
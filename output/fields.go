@@ -0,0 +1,298 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+type Field struct {
+	Key   string
+	Label string
+}
+
+type fieldDefinition struct {
+	name       string
+	header     string
+	alignRight bool
+	value      func(models.Property) interface{}
+	text       func(models.Property) string
+	display    func(models.Property) string
+	// Property field the value is read from, empty for computed fields.
+	column string
+	// Set on the map of all amenities, which spreadsheets expand to one column each.
+	expandsAmmenities bool
+}
+
+type resolvedField struct {
+	fieldDefinition
+	label   string
+	renamed bool
+}
+
+var (
+	defaultJSONFields  = []string{"squareFootage", "lighting", "price", "rooms", "bathrooms", "location", "description", "ammenities"}
+	defaultCSVFields   = []string{"squareFootage", "lighting", "price", "rooms", "bathrooms", "latitude", "longitude", "description", "ammenities"}
+	defaultTableFields = []string{"score", "price", "sqft", "rooms", "bathrooms", "lighting", "amenities", "description"}
+)
+
+var fieldDefinitions = map[string]fieldDefinition{
+	"squarefootage": {
+		name:       "squareFootage",
+		column:     "squareFootage",
+		header:     "SQFT",
+		alignRight: true,
+		value:      func(p models.Property) interface{} { return p.SquareFootage },
+		text:       func(p models.Property) string { return fmt.Sprintf("%d", int(p.SquareFootage)) },
+		display:    func(p models.Property) string { return formatNumber(p.SquareFootage, 0) },
+	},
+	"lighting": {
+		name:    "lighting",
+		column:  "lighting",
+		header:  "LIGHTING",
+		value:   func(p models.Property) interface{} { return p.Lighting },
+		text:    func(p models.Property) string { return p.Lighting },
+		display: func(p models.Property) string { return p.Lighting },
+	},
+	"price": {
+		name:       "price",
+		column:     "price",
+		header:     "PRICE",
+		alignRight: true,
+		value:      func(p models.Property) interface{} { return p.Price },
+		text:       func(p models.Property) string { return fmt.Sprintf("%.2f", p.Price) },
+		display:    func(p models.Property) string { return formatMoney(p.Price) },
+	},
+	"rooms": {
+		name:       "rooms",
+		column:     "rooms",
+		header:     "ROOMS",
+		alignRight: true,
+		value:      func(p models.Property) interface{} { return p.Rooms },
+		text:       func(p models.Property) string { return fmt.Sprintf("%d", int(p.Rooms)) },
+		display:    func(p models.Property) string { return strconv.FormatFloat(p.Rooms, 'f', -1, 64) },
+	},
+	"bathrooms": {
+		name:       "bathrooms",
+		column:     "bathrooms",
+		header:     "BATHS",
+		alignRight: true,
+		value:      func(p models.Property) interface{} { return p.Bathrooms },
+		text:       func(p models.Property) string { return fmt.Sprintf("%d", int(p.Bathrooms)) },
+		display:    func(p models.Property) string { return strconv.FormatFloat(p.Bathrooms, 'f', -1, 64) },
+	},
+	"location": {
+		name:    "location",
+		column:  "location",
+		header:  "LOCATION",
+		value:   func(p models.Property) interface{} { return p.Location },
+		text:    func(p models.Property) string { return fmt.Sprintf("%.6f,%.6f", p.Location[0], p.Location[1]) },
+		display: func(p models.Property) string { return fmt.Sprintf("%.4f,%.4f", p.Location[0], p.Location[1]) },
+	},
+	"latitude": {
+		name:       "latitude",
+		column:     "location",
+		header:     "LAT",
+		alignRight: true,
+		value:      func(p models.Property) interface{} { return p.Location[0] },
+		text:       func(p models.Property) string { return fmt.Sprintf("%.6f", p.Location[0]) },
+		display:    func(p models.Property) string { return fmt.Sprintf("%.4f", p.Location[0]) },
+	},
+	"longitude": {
+		name:       "longitude",
+		column:     "location",
+		header:     "LONG",
+		alignRight: true,
+		value:      func(p models.Property) interface{} { return p.Location[1] },
+		text:       func(p models.Property) string { return fmt.Sprintf("%.6f", p.Location[1]) },
+		display:    func(p models.Property) string { return fmt.Sprintf("%.4f", p.Location[1]) },
+	},
+	"description": {
+		name:    "description",
+		column:  "description",
+		header:  "DESCRIPTION",
+		value:   func(p models.Property) interface{} { return p.Description },
+		text:    func(p models.Property) string { return p.Description },
+		display: func(p models.Property) string { return p.Description },
+	},
+	"ammenities": {
+		name:   "ammenities",
+		column: "ammenities",
+		header: "AMENITIES",
+		value:  func(p models.Property) interface{} { return p.Ammenities },
+		text: func(p models.Property) string {
+			ammenitiesJSON, _ := json.Marshal(p.Ammenities)
+			return string(ammenitiesJSON)
+		},
+		display:           func(p models.Property) string { return strings.Join(availableAmmenities(p.Ammenities), ",") },
+		expandsAmmenities: true,
+	},
+	"source": {
+		name:    "source",
+		header:  "SOURCE",
+		value:   func(p models.Property) interface{} { return p.Source },
+		text:    func(p models.Property) string { return p.Source },
+		display: func(p models.Property) string { return p.Source },
+	},
+	"score": {
+		name:       "score",
+		header:     "SCORE",
+		alignRight: true,
+		value: func(p models.Property) interface{} {
+			if p.Score == nil {
+				return nil
+			}
+			return *p.Score
+		},
+		text: func(p models.Property) string {
+			if p.Score == nil {
+				return ""
+			}
+			return fmt.Sprintf("%.4f", *p.Score)
+		},
+		display: func(p models.Property) string {
+			if p.Score == nil {
+				return ""
+			}
+			return strconv.FormatFloat(*p.Score, 'f', 3, 64)
+		},
+	},
+}
+
+var fieldAliases = map[string]string{
+	"sqft":      "squarefootage",
+	"lat":       "latitude",
+	"long":      "longitude",
+	"amenities": "ammenities",
+}
+
+// ParseFields parses a comma-separated list of fields, each optionally
+// renamed with "field:Name".
+func ParseFields(s string) ([]Field, error) {
+	var fields []Field
+	for _, item := range strings.Split(s, ",") {
+		key, label, _ := strings.Cut(item, ":")
+		key = strings.TrimSpace(key)
+		label = strings.TrimSpace(label)
+
+		if key == "" {
+			return nil, fmt.Errorf("empty field in: %s", s)
+		}
+		if _, ok := lookupField(key, Options{}); !ok {
+			return nil, fmt.Errorf("invalid field: %s", key)
+		}
+
+		fields = append(fields, Field{Key: key, Label: label})
+	}
+
+	return fields, nil
+}
+
+// Columns returns the Property fields read by the given output fields, in
+// order and without duplicates.
+func Columns(fields []Field) []string {
+	var columns []string
+	seen := map[string]bool{}
+	for _, field := range fields {
+		definition, ok := lookupField(field.Key, Options{})
+		if !ok || definition.column == "" || seen[definition.column] {
+			continue
+		}
+		seen[definition.column] = true
+		columns = append(columns, definition.column)
+	}
+	return columns
+}
+
+func lookupField(key string, options Options) (fieldDefinition, bool) {
+	normalizedKey := strings.ToLower(strings.TrimSpace(key))
+
+	if prefix, name, ok := strings.Cut(normalizedKey, "."); ok {
+		if prefix != models.AmmenitiesKey && prefix != models.AmenitiesKey || name == "" {
+			return fieldDefinition{}, false
+		}
+		return ammenityField(name), true
+	}
+
+	if alias, ok := fieldAliases[normalizedKey]; ok {
+		normalizedKey = alias
+	}
+	definition, ok := fieldDefinitions[normalizedKey]
+	if ok && normalizedKey == "ammenities" {
+		definition.name = options.ammenitiesKey()
+	}
+
+	return definition, ok
+}
+
+func ammenityField(name string) fieldDefinition {
+	return fieldDefinition{
+		name:   name,
+		column: "ammenities",
+		header: strings.ToUpper(name),
+		value: func(p models.Property) interface{} {
+			if value, ok := p.Ammenities[name]; ok {
+				return value
+			}
+			return nil
+		},
+		text: func(p models.Property) string {
+			if value, ok := p.Ammenities[name]; ok {
+				return strconv.FormatBool(value)
+			}
+			return ""
+		},
+		display: func(p models.Property) string {
+			if value, ok := p.Ammenities[name]; ok {
+				return strconv.FormatBool(value)
+			}
+			return ""
+		},
+	}
+}
+
+func resolveFields(data []models.Property, options Options, defaults []string) ([]resolvedField, error) {
+	fields := options.Fields
+	if len(fields) == 0 {
+		// Scores are appended unless the defaults place them elsewhere.
+		scored := len(data) > 0 && data[0].Score != nil
+		for _, key := range defaults {
+			if key == "score" && !scored {
+				continue
+			}
+			fields = append(fields, Field{Key: key})
+		}
+		if scored && !slices.Contains(defaults, "score") {
+			fields = append(fields, Field{Key: "score"})
+		}
+	}
+
+	resolved := make([]resolvedField, len(fields))
+	for i, field := range fields {
+		definition, ok := lookupField(field.Key, options)
+		if !ok {
+			return nil, fmt.Errorf("invalid field: %s", field.Key)
+		}
+
+		resolved[i] = resolvedField{fieldDefinition: definition, label: field.Label, renamed: field.Label != ""}
+		if !resolved[i].renamed {
+			resolved[i].label = field.Key
+			if len(options.Fields) == 0 {
+				resolved[i].label = definition.name
+			}
+		}
+	}
+
+	return resolved, nil
+}
+
+func (f resolvedField) tableHeader() string {
+	if f.renamed {
+		return f.label
+	}
+	return f.header
+}
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestWriteNDJSONFields(t *testing.T) {
+	data := []models.Property{
+		{Price: 250000, SquareFootage: 1200, Location: [2]float64{34.05, -118.24}, Ammenities: map[string]bool{"pool": true}},
+		{Price: 90000, SquareFootage: 500, Ammenities: map[string]bool{}},
+	}
+
+	var buf bytes.Buffer
+	options := Options{Fields: []Field{{Key: "price", Label: "Price USD"}, {Key: "sqft"}, {Key: "amenities.pool"}}}
+	if err := WriteNDJSON(context.Background(), &buf, data, options); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	expected := `{"Price USD":250000,"sqft":1200,"amenities.pool":true}
+{"Price USD":90000,"sqft":500,"amenities.pool":null}
+`
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestToCSVFileFields(t *testing.T) {
+	data := []models.Property{
+		{Price: 250000, Location: [2]float64{34.05, -118.24}, Ammenities: map[string]bool{"garage": false}},
+	}
+
+	path := filepath.Join(t.TempDir(), "out.csv")
+	options := Options{Fields: []Field{{Key: "lat"}, {Key: "long"}, {Key: "price", Label: "Price USD"}, {Key: "ammenities.garage"}}}
+	if err := ToCSVFile(context.Background(), data, path, options); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]string{
+		{"lat", "long", "Price USD", "ammenities.garage"},
+		{"34.050000", "-118.240000", "250000.00", "false"},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+}
+
+func TestResolveFieldsInvalid(t *testing.T) {
+	if _, err := resolveFields(nil, Options{Fields: []Field{{Key: "garden"}}}, defaultJSONFields); err == nil {
+		t.Errorf("expected error but got nil")
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  []Field
+		expectErr bool
+	}{
+		{
+			input:    "price, sqft,location",
+			expected: []Field{{Key: "price"}, {Key: "sqft"}, {Key: "location"}},
+		},
+		{
+			input:    "price:Price USD,amenities.pool:Pool",
+			expected: []Field{{Key: "price", Label: "Price USD"}, {Key: "amenities.pool", Label: "Pool"}},
+		},
+		{
+			input:    "squareFootage,ammenities.garage",
+			expected: []Field{{Key: "squareFootage"}, {Key: "ammenities.garage"}},
+		},
+		{
+			input:     "price,garden",
+			expectErr: true,
+		},
+		{
+			input:     "price,,rooms",
+			expectErr: true,
+		},
+		{
+			input:     "amenities.",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := ParseFields(test.input)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("did not expect error but got: %v", err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+// encoder checks data and options and returns a function that writes them, so
+// invalid options are reported before an output file is created.
+type encoder func(data []models.Property, options Options) (func(io.Writer) error, error)
+
+func toFile(ctx context.Context, path string, data []models.Property, options Options, encode encoder) error {
+	write, err := encode(data, options)
+	if err != nil {
+		return err
+	}
+	return WriteFile(ctx, path, options, write)
+}
+
+func toWriter(ctx context.Context, w io.Writer, data []models.Property, options Options, encode encoder) error {
+	write, err := encode(data, options)
+	if err != nil {
+		return err
+	}
+	return write(withContext(ctx, w))
+}
+
+// WriteFile writes path with write, compressed according to
+// options.Compression. It's written to a temporary file in the same directory
+// that's renamed to path once it's complete, so path is never left partially
+// written: a failed write, or one stopped because ctx is done, leaves it as
+// it was. An existing file is only replaced when options.Overwrite is set.
+func WriteFile(ctx context.Context, path string, options Options, write func(io.Writer) error) error {
+	if options.Append {
+		return fmt.Errorf("appending is only supported for CSV and NDJSON files")
+	}
+	if err := checkOverwrite(path, options); err != nil {
+		return err
+	}
+
+	// Replaced files keep their permissions, new ones get the usual 0644.
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create file: %v", err)
+	}
+	tempPath := file.Name()
+	defer os.Remove(tempPath)
+	defer file.Close()
+
+	writer, err := compress(file, options)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	if err := write(withContext(ctx, writer)); err != nil {
+		return err
+	}
+
+	// Close errors are returned, since that's when compressed data is
+	// flushed. Closing the writer also closes the file.
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("could not write file: %v", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tempPath, mode); err != nil {
+		return fmt.Errorf("could not set file permissions: %v", err)
+	}
+	if err := checkOverwrite(path, options); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("could not write file: %v", err)
+	}
+
+	return nil
+}
+
+func checkOverwrite(path string, options Options) error {
+	if options.Overwrite {
+		return nil
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	return nil
+}
+
+// appendFile appends to path with write. If writing fails, or stops because
+// ctx is done, the file is truncated back to its original size.
+func appendFile(ctx context.Context, path string, options Options, write func(io.Writer) error) error {
+	if options.Compression != "" {
+		return fmt.Errorf("compressed files can't be appended to")
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return fmt.Errorf("could not open file: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("could not open file: %v", err)
+	}
+
+	err = write(withContext(ctx, file))
+	if err == nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		if truncateErr := file.Truncate(info.Size()); truncateErr != nil {
+			return fmt.Errorf("%v, and could not restore the file: %v", err, truncateErr)
+		}
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("could not write file: %v", err)
+	}
+	return nil
+}
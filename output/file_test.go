@@ -0,0 +1,170 @@
+package output
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestToFileOverwrite(t *testing.T) {
+	data := []models.Property{{Price: 250000, SquareFootage: 1200}}
+	options := Options{Fields: []Field{{Key: "price"}, {Key: "sqft"}}}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.ndjson")
+	if err := os.WriteFile(path, []byte("existing\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ToNDJSONFile(context.Background(), data, path, options); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected an already exists error, got %v", err)
+	}
+	assertFileContents(t, path, "existing\n")
+
+	options.Overwrite = true
+	if err := ToNDJSONFile(context.Background(), data, path, options); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+	assertFileContents(t, path, "{\"price\":250000,\"sqft\":1200}\n")
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected the file mode to be kept, got %v", info.Mode().Perm())
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func TestToFileFailureKeepsExisting(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.json")
+	if err := os.WriteFile(path, []byte("existing\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	data := []models.Property{{Price: 250000}}
+	if err := ToJSONFile(ctx, data, path, Options{Overwrite: true}); err == nil {
+		t.Errorf("expected error but got nil")
+	}
+	assertFileContents(t, path, "existing\n")
+	assertNoTempFiles(t, dir)
+}
+
+func TestAppend(t *testing.T) {
+	first := []models.Property{{Price: 250000, Rooms: 3}}
+	second := []models.Property{{Price: 90000, Rooms: 1}}
+	fields := []Field{{Key: "price", Label: "Price"}, {Key: "rooms"}}
+
+	tests := []struct {
+		name     string
+		file     string
+		write    func(context.Context, []models.Property, string, Options) error
+		exists   bool
+		existing string
+		expected string
+		err      string
+	}{
+		{
+			name:     "csv",
+			file:     "out.csv",
+			write:    ToCSVFile,
+			expected: "Price,rooms\n250000.00,3\n90000.00,1\n",
+		},
+		{
+			name:     "csv empty file",
+			file:     "out.csv",
+			write:    ToCSVFile,
+			exists:   true,
+			expected: "Price,rooms\n250000.00,3\n90000.00,1\n",
+		},
+		{
+			name:     "csv header mismatch",
+			file:     "out.csv",
+			write:    ToCSVFile,
+			exists:   true,
+			existing: "price,rooms\n1.00,1\n",
+			err:      `its header is "price,rooms", expected "Price,rooms"`,
+		},
+		{
+			name:     "ndjson",
+			file:     "out.ndjson",
+			write:    ToNDJSONFile,
+			expected: "{\"Price\":250000,\"rooms\":3}\n{\"Price\":90000,\"rooms\":1}\n",
+		},
+		{
+			name:     "ndjson key mismatch",
+			file:     "out.ndjson",
+			write:    ToNDJSONFile,
+			exists:   true,
+			existing: "{\"rooms\":1,\"Price\":1}\n",
+			err:      `its records have keys "rooms,Price", expected "Price,rooms"`,
+		},
+		{
+			name:     "json",
+			file:     "out.json",
+			write:    ToJSONFile,
+			exists:   true,
+			existing: "[]\n",
+			err:      "appending is only supported for CSV and NDJSON files",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), test.file)
+			options := Options{Fields: fields, Append: true}
+
+			if test.exists {
+				if err := os.WriteFile(path, []byte(test.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if test.err != "" {
+				err := test.write(context.Background(), second, path, options)
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("expected error containing %q, got %v", test.err, err)
+				}
+				assertFileContents(t, path, test.existing)
+				return
+			}
+
+			for _, data := range [][]models.Property{first, second} {
+				if err := test.write(context.Background(), data, path, options); err != nil {
+					t.Fatalf("did not expect error but got: %v", err)
+				}
+			}
+			assertFileContents(t, path, test.expected)
+		})
+	}
+}
+
+func assertFileContents(t *testing.T, path, expected string) {
+	t.Helper()
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(contents) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, contents)
+	}
+}
+
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, ".*.tmp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) > 0 {
+		t.Errorf("expected no temporary files, got %v", matches)
+	}
+}
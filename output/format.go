@@ -0,0 +1,54 @@
+package output
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func formatNumber(v float64, decimals int) string {
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	if hasFraction {
+		return sign + grouped.String() + "." + fraction
+	}
+	return sign + grouped.String()
+}
+
+// formatPlain formats v with as many decimals as needed and no exponent, so it
+// can be pasted into SQL or CSV.
+func formatPlain(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatMoney(v float64) string {
+	if v < 0 {
+		return "-$" + formatNumber(-v, 2)
+	}
+	return "$" + formatNumber(v, 2)
+}
+
+func availableAmmenities(ammenities map[string]bool) []string {
+	var available []string
+	for name, value := range ammenities {
+		if value {
+			available = append(available, name)
+		}
+	}
+	sort.Strings(available)
+	return available
+}
@@ -0,0 +1,42 @@
+package output
+
+import "testing"
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		value    float64
+		decimals int
+		expected string
+	}{
+		{0, 0, "0"},
+		{999, 0, "999"},
+		{1000, 0, "1,000"},
+		{250000, 2, "250,000.00"},
+		{1234567.891, 2, "1,234,567.89"},
+		{-1500, 0, "-1,500"},
+	}
+
+	for _, tt := range tests {
+		result := formatNumber(tt.value, tt.decimals)
+		if result != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, result)
+		}
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{250000, "$250,000.00"},
+		{-12.5, "-$12.50"},
+	}
+
+	for _, tt := range tests {
+		result := formatMoney(tt.value)
+		if result != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, result)
+		}
+	}
+}
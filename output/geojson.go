@@ -0,0 +1,75 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+type feature struct {
+	Type       string   `json:"type"`
+	Geometry   geometry `json:"geometry"`
+	Properties record   `json:"properties"`
+}
+
+type geometry struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+func ToGeoJSONFile(ctx context.Context, data []models.Property, path string, options Options) error {
+	return toFile(ctx, path, data, options, encodeGeoJSON)
+}
+
+func WriteGeoJSON(ctx context.Context, w io.Writer, data []models.Property, options Options) error {
+	return toWriter(ctx, w, data, options, encodeGeoJSON)
+}
+
+func encodeGeoJSON(data []models.Property, options Options) (func(io.Writer) error, error) {
+	fields, err := resolveFields(data, options, defaultJSONFields)
+	if err != nil {
+		return nil, err
+	}
+
+	var propertyFields []resolvedField
+	for _, field := range fields {
+		switch field.name {
+		case "location", "latitude", "longitude":
+		default:
+			propertyFields = append(propertyFields, field)
+		}
+	}
+
+	collection := featureCollection{
+		Type:     "FeatureCollection",
+		Features: []feature{},
+	}
+
+	for _, property := range data {
+		collection.Features = append(collection.Features, feature{
+			Type: "Feature",
+			Geometry: geometry{
+				Type:        "Point",
+				Coordinates: [2]float64{property.Location[1], property.Location[0]},
+			},
+			Properties: toRecord(property, propertyFields),
+		})
+	}
+
+	return func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(collection); err != nil {
+			return fmt.Errorf("could not encode data to GeoJSON: %v", err)
+		}
+		return nil
+	}, nil
+}
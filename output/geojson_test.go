@@ -0,0 +1,74 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestWriteGeoJSON(t *testing.T) {
+	data := []models.Property{
+		{Price: 250000, Rooms: 3, Location: [2]float64{34.05, -118.24}, Description: "Bright loft"},
+		{Price: 90000, Rooms: 1, Location: [2]float64{-34.6037, -58.3816}, Description: "Studio"},
+	}
+
+	var buf bytes.Buffer
+	options := Options{Fields: []Field{{Key: "price"}, {Key: "location"}, {Key: "rooms"}}}
+	if err := WriteGeoJSON(context.Background(), &buf, data, options); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	// Coordinates are [longitude, latitude], and the location isn't repeated
+	// in the properties.
+	expected := `{
+  "type": "FeatureCollection",
+  "features": [
+    {
+      "type": "Feature",
+      "geometry": {
+        "type": "Point",
+        "coordinates": [
+          -118.24,
+          34.05
+        ]
+      },
+      "properties": {
+        "price": 250000,
+        "rooms": 3
+      }
+    },
+    {
+      "type": "Feature",
+      "geometry": {
+        "type": "Point",
+        "coordinates": [
+          -58.3816,
+          -34.6037
+        ]
+      },
+      "properties": {
+        "price": 90000,
+        "rooms": 1
+      }
+    }
+  ]
+}
+`
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteGeoJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGeoJSON(context.Background(), &buf, nil, Options{}); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	expected := "{\n  \"type\": \"FeatureCollection\",\n  \"features\": []\n}\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
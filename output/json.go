@@ -1,31 +1,42 @@
 package output
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
 )
 
-func ToJSONFile(data interface{}, path string) error {
-	file, err := os.Create(path)
+func ToJSONFile(ctx context.Context, data []models.Property, path string, options Options) error {
+	return toFile(ctx, path, data, options, encodeJSON)
+}
+
+func WriteJSON(ctx context.Context, w io.Writer, data []models.Property, options Options) error {
+	return toWriter(ctx, w, data, options, encodeJSON)
+}
+
+func encodeJSON(data []models.Property, options Options) (func(io.Writer) error, error) {
+	fields, err := resolveFields(data, options, defaultJSONFields)
 	if err != nil {
-		return fmt.Errorf("could not create file: %v", err)
+		return nil, err
 	}
-	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(data); err != nil {
-		return fmt.Errorf("could not encode data to JSON: %v", err)
-	}
+	return func(w io.Writer) error {
+		return writeJSON(w, data, fields)
+	}, nil
+}
 
-	return nil
+func ToJSONStdOut(ctx context.Context, data []models.Property, options Options) error {
+	return WriteJSON(ctx, os.Stdout, data, options)
 }
 
-func ToJSONStdOut(data interface{}) error {
-	encoder := json.NewEncoder(os.Stdout)
+func writeJSON(w io.Writer, data []models.Property, fields []resolvedField) error {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(data); err != nil {
+	if err := encoder.Encode(toRecords(data, fields)); err != nil {
 		return fmt.Errorf("could not encode data to JSON: %v", err)
 	}
 
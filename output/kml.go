@@ -0,0 +1,212 @@
+package output
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+const (
+	KMLStyleLighting = "lighting"
+	KMLStylePrice    = "price"
+)
+
+var defaultKMLNameFields = []string{"price", "rooms"}
+
+var kmlBandColors = map[string]string{
+	"low":    "ff00ff00",
+	"medium": "ff00ffff",
+	"high":   "ff0000ff",
+}
+
+type kmlDocument struct {
+	XMLName  xml.Name     `xml:"kml"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Document kmlContainer `xml:"Document"`
+}
+
+type kmlContainer struct {
+	Name       string         `xml:"name"`
+	Styles     []kmlStyle     `xml:"Style"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlStyle struct {
+	ID    string `xml:"id,attr"`
+	Color string `xml:"IconStyle>color"`
+	Icon  string `xml:"IconStyle>Icon>href"`
+}
+
+type kmlPlacemark struct {
+	Name        string   `xml:"name"`
+	Description kmlCDATA `xml:"description"`
+	StyleURL    string   `xml:"styleUrl,omitempty"`
+	Coordinates string   `xml:"Point>coordinates"`
+}
+
+type kmlCDATA struct {
+	Text string `xml:",cdata"`
+}
+
+func ToKMLFile(ctx context.Context, data []models.Property, path string, options Options) error {
+	return toFile(ctx, path, data, options, encodeKML)
+}
+
+func WriteKML(ctx context.Context, w io.Writer, data []models.Property, options Options) error {
+	return toWriter(ctx, w, data, options, encodeKML)
+}
+
+func encodeKML(data []models.Property, options Options) (func(io.Writer) error, error) {
+	if err := ValidateKMLOptions(options); err != nil {
+		return nil, err
+	}
+	nameFields := options.KMLNameFields
+	if len(nameFields) == 0 {
+		nameFields = defaultKMLNameFields
+	}
+
+	var band func(models.Property) string
+	switch options.KMLStyle {
+	case KMLStyleLighting:
+		band = func(property models.Property) string {
+			return strings.ToLower(strings.TrimSpace(property.Lighting))
+		}
+	case KMLStylePrice:
+		band = priceBands(data)
+	}
+
+	document := kmlDocument{
+		Xmlns:    "http://www.opengis.net/kml/2.2",
+		Document: kmlContainer{Name: "Properties"},
+	}
+
+	if band != nil {
+		for _, level := range models.LightingLevels {
+			document.Document.Styles = append(document.Document.Styles, kmlStyle{
+				ID:    level,
+				Color: kmlBandColors[level],
+				Icon:  "http://maps.google.com/mapfiles/kml/paddle/wht-blank.png",
+			})
+		}
+	}
+
+	for _, property := range data {
+		var nameParts []string
+		for _, field := range nameFields {
+			part, _ := kmlNamePart(property, field)
+			nameParts = append(nameParts, part)
+		}
+
+		placemark := kmlPlacemark{
+			Name:        strings.Join(nameParts, ", "),
+			Description: kmlCDATA{kmlDescription(property)},
+			Coordinates: fmt.Sprintf("%f,%f,0", property.Location[1], property.Location[0]),
+		}
+		if band != nil {
+			if level := band(property); kmlBandColors[level] != "" {
+				placemark.StyleURL = "#" + level
+			}
+		}
+
+		document.Document.Placemarks = append(document.Document.Placemarks, placemark)
+	}
+
+	return func(w io.Writer) error {
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return fmt.Errorf("could not write KML header: %v", err)
+		}
+
+		encoder := xml.NewEncoder(w)
+		encoder.Indent("", "  ")
+		if err := encoder.Encode(document); err != nil {
+			return fmt.Errorf("could not encode data to KML: %v", err)
+		}
+		return nil
+	}, nil
+}
+
+// ValidateKMLOptions checks the KML name fields and style, so they can be
+// rejected before any input is read.
+func ValidateKMLOptions(options Options) error {
+	for _, field := range options.KMLNameFields {
+		if _, err := kmlNamePart(models.Property{}, field); err != nil {
+			return err
+		}
+	}
+	switch options.KMLStyle {
+	case "", KMLStyleLighting, KMLStylePrice:
+		return nil
+	default:
+		return fmt.Errorf("invalid KML style: %s", options.KMLStyle)
+	}
+}
+
+func kmlNamePart(property models.Property, field string) (string, error) {
+	switch strings.ToLower(field) {
+	case "price":
+		return formatMoney(property.Price), nil
+	case "squarefootage", "sqft":
+		return formatNumber(property.SquareFootage, 0) + " sqft", nil
+	case "rooms":
+		return fmt.Sprintf("%g rooms", property.Rooms), nil
+	case "bathrooms":
+		return fmt.Sprintf("%g bathrooms", property.Bathrooms), nil
+	case "lighting":
+		return property.Lighting + " lighting", nil
+	case "description":
+		return property.Description, nil
+	default:
+		return "", fmt.Errorf("invalid KML name field: %s", field)
+	}
+}
+
+func kmlDescription(property models.Property) string {
+	rows := [][2]string{
+		{"Price", formatMoney(property.Price)},
+		{"Size", formatNumber(property.SquareFootage, 0) + " sqft"},
+		{"Rooms", fmt.Sprintf("%g", property.Rooms)},
+		{"Bathrooms", fmt.Sprintf("%g", property.Bathrooms)},
+		{"Lighting", property.Lighting},
+		{"Amenities", strings.Join(availableAmmenities(property.Ammenities), ", ")},
+	}
+
+	var description strings.Builder
+	description.WriteString("<p>" + html.EscapeString(property.Description) + "</p><table>")
+	for _, row := range rows {
+		description.WriteString("<tr><th align=\"left\">" + row[0] + "</th><td>" + html.EscapeString(row[1]) + "</td></tr>")
+	}
+	description.WriteString("</table>")
+
+	return description.String()
+}
+
+func priceBands(data []models.Property) func(models.Property) string {
+	prices := make([]float64, len(data))
+	for i, property := range data {
+		prices[i] = property.Price
+	}
+	sort.Float64s(prices)
+
+	if len(prices) == 0 {
+		return func(models.Property) string { return "" }
+	}
+	lowerThird := prices[len(prices)/3]
+	upperThird := prices[2*len(prices)/3]
+
+	return func(property models.Property) string {
+		switch {
+		case property.Price < lowerThird:
+			return "low"
+		case property.Price < upperThird:
+			return "medium"
+		default:
+			return "high"
+		}
+	}
+}
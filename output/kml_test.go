@@ -0,0 +1,107 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestWriteKML(t *testing.T) {
+	data := []models.Property{
+		{
+			Price:       250000,
+			Rooms:       3,
+			Lighting:    "high",
+			Location:    [2]float64{34.05, -118.24},
+			Description: `Loft <b>"bright"</b> & airy ]]> no CDATA escape`,
+			Ammenities:  map[string]bool{"pool": true, "garage": false},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteKML(context.Background(), &buf, data, Options{KMLStyle: KMLStyleLighting}); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	// Coordinates are longitude,latitude,altitude, and the description's HTML
+	// is escaped so it can't close the CDATA section or inject markup.
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <name>Properties</name>
+    <Style id="low">
+      <IconStyle>
+        <color>ff00ff00</color>
+        <Icon>
+          <href>http://maps.google.com/mapfiles/kml/paddle/wht-blank.png</href>
+        </Icon>
+      </IconStyle>
+    </Style>
+    <Style id="medium">
+      <IconStyle>
+        <color>ff00ffff</color>
+        <Icon>
+          <href>http://maps.google.com/mapfiles/kml/paddle/wht-blank.png</href>
+        </Icon>
+      </IconStyle>
+    </Style>
+    <Style id="high">
+      <IconStyle>
+        <color>ff0000ff</color>
+        <Icon>
+          <href>http://maps.google.com/mapfiles/kml/paddle/wht-blank.png</href>
+        </Icon>
+      </IconStyle>
+    </Style>
+    <Placemark>
+      <name>$250,000.00, 3 rooms</name>
+      <description><![CDATA[<p>Loft &lt;b&gt;&#34;bright&#34;&lt;/b&gt; &amp; airy ]]&gt; no CDATA escape</p><table><tr><th align="left">Price</th><td>$250,000.00</td></tr><tr><th align="left">Size</th><td>0 sqft</td></tr><tr><th align="left">Rooms</th><td>3</td></tr><tr><th align="left">Bathrooms</th><td>0</td></tr><tr><th align="left">Lighting</th><td>high</td></tr><tr><th align="left">Amenities</th><td>pool</td></tr></table>]]></description>
+      <styleUrl>#high</styleUrl>
+      <Point>
+        <coordinates>-118.240000,34.050000,0</coordinates>
+      </Point>
+    </Placemark>
+  </Document>
+</kml>`
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteKMLInvalidOptions(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteKML(context.Background(), &buf, nil, Options{KMLNameFields: []string{"garden"}}); err == nil {
+		t.Errorf("expected error for invalid name field but got nil")
+	}
+	if err := WriteKML(context.Background(), &buf, nil, Options{KMLStyle: "rainbow"}); err == nil {
+		t.Errorf("expected error for invalid style but got nil")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing to be written, got %q", buf.String())
+	}
+}
+
+func TestValidateKMLOptions(t *testing.T) {
+	tests := []struct {
+		options   Options
+		expectErr bool
+	}{
+		{options: Options{}},
+		{options: Options{KMLNameFields: []string{"squareFootage", "ROOMS"}, KMLStyle: KMLStylePrice}},
+		{options: Options{KMLNameFields: []string{"squarefootage", "sqft"}}},
+		{options: Options{KMLNameFields: []string{"garden"}}, expectErr: true},
+		{options: Options{KMLStyle: "rainbow"}, expectErr: true},
+	}
+
+	for _, test := range tests {
+		err := ValidateKMLOptions(test.options)
+		if test.expectErr && err == nil {
+			t.Errorf("expected error for %+v but got nil", test.options)
+		}
+		if !test.expectErr && err != nil {
+			t.Errorf("did not expect error for %+v but got: %v", test.options, err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func ToNDJSONFile(ctx context.Context, data []models.Property, path string, options Options) error {
+	if options.Append {
+		return appendNDJSONFile(ctx, data, path, options)
+	}
+	return toFile(ctx, path, data, options, encodeNDJSON)
+}
+
+func WriteNDJSON(ctx context.Context, w io.Writer, data []models.Property, options Options) error {
+	return toWriter(ctx, w, data, options, encodeNDJSON)
+}
+
+func ToNDJSONStdOut(ctx context.Context, data []models.Property, options Options) error {
+	return WriteNDJSON(ctx, os.Stdout, data, options)
+}
+
+func encodeNDJSON(data []models.Property, options Options) (func(io.Writer) error, error) {
+	fields, err := resolveFields(data, options, defaultJSONFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer) error {
+		return writeNDJSON(w, data, fields)
+	}, nil
+}
+
+// appendNDJSONFile adds records to the NDJSON file at path, after checking
+// that the keys of its first record match the fields written.
+func appendNDJSONFile(ctx context.Context, data []models.Property, path string, options Options) error {
+	fields, err := resolveFields(data, options, defaultJSONFields)
+	if err != nil {
+		return err
+	}
+
+	existing, err := readNDJSONKeys(path)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		options.Append = false
+		options.Overwrite = true
+		return WriteFile(ctx, path, options, func(w io.Writer) error {
+			return writeNDJSON(w, data, fields)
+		})
+	}
+
+	keys := make([]string, len(fields))
+	for i, field := range fields {
+		keys[i] = field.label
+	}
+	if strings.Join(existing, ",") != strings.Join(keys, ",") {
+		return fmt.Errorf("can't append to %s: its records have keys %q, expected %q", path, strings.Join(existing, ","), strings.Join(keys, ","))
+	}
+
+	return appendFile(ctx, path, options, func(w io.Writer) error {
+		return writeNDJSON(w, data, fields)
+	})
+}
+
+// readNDJSONKeys returns the keys of the first record in the NDJSON file at
+// path, in order, or nil if it doesn't exist or is empty.
+func readNDJSONKeys(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open file: %v", err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if delim, ok := token.(json.Delim); err != nil || !ok || delim != '{' {
+		return nil, fmt.Errorf("could not read the first record of %s: expected a JSON object", path)
+	}
+
+	keys := []string{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("could not read the first record of %s: %v", path, err)
+		}
+		keys = append(keys, token.(string))
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("could not read the first record of %s: %v", path, err)
+		}
+	}
+	return keys, nil
+}
+
+func writeNDJSON(w io.Writer, data []models.Property, fields []resolvedField) error {
+	encoder := json.NewEncoder(w)
+	for _, property := range data {
+		if err := encoder.Encode(toRecord(property, fields)); err != nil {
+			return fmt.Errorf("could not encode data to NDJSON: %v", err)
+		}
+	}
+	return nil
+}
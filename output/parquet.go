@@ -0,0 +1,49 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+// Smaller row groups let readers skip more of the file using the per-group
+// column statistics, at the cost of some compression.
+const parquetRowGroupSize = 10000
+
+func ToParquetFile(ctx context.Context, data []models.Property, path string, options Options) error {
+	return toFile(ctx, path, data, options, encodeParquet)
+}
+
+func WriteParquet(ctx context.Context, w io.Writer, data []models.Property, options Options) error {
+	return toWriter(ctx, w, data, options, encodeParquet)
+}
+
+func encodeParquet(data []models.Property, options Options) (func(io.Writer) error, error) {
+	return func(w io.Writer) error {
+		return writeParquet(w, data)
+	}, nil
+}
+
+func writeParquet(w io.Writer, data []models.Property) error {
+	writer := parquet.NewGenericWriter[models.ParquetProperty](w,
+		parquet.Compression(&parquet.Snappy),
+		parquet.MaxRowsPerRowGroup(parquetRowGroupSize),
+	)
+
+	rows := make([]models.ParquetProperty, len(data))
+	for i, property := range data {
+		rows[i] = models.NewParquetProperty(property)
+	}
+
+	if _, err := writer.Write(rows); err != nil {
+		return fmt.Errorf("could not write Parquet rows: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("could not write Parquet file: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package output
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestToParquetFile(t *testing.T) {
+	data := []models.Property{
+		{SquareFootage: 1200, Lighting: "high", Price: 250000, Rooms: 3, Bathrooms: 2, Location: [2]float64{34.05, -118.24}, Description: "Bright loft", Ammenities: map[string]bool{"pool": true, "garage": false, "yard": true}},
+		{SquareFootage: 800, Lighting: "low", Price: 90000, Rooms: 1, Bathrooms: 1, Location: [2]float64{40.71, -74.0}, Description: "Studio", Ammenities: map[string]bool{}},
+	}
+
+	path := filepath.Join(t.TempDir(), "out.parquet")
+	if err := ToParquetFile(context.Background(), data, path, Options{}); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	rows, err := parquet.ReadFile[models.ParquetProperty](path)
+	if err != nil {
+		t.Fatalf("did not expect error reading back but got: %v", err)
+	}
+	expected := []models.ParquetProperty{models.NewParquetProperty(data[0]), models.NewParquetProperty(data[1])}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+type Options struct {
+	AmmenitiesKey string
+	KMLNameFields []string
+	KMLStyle      string
+	Fields        []Field
+	Columns       []string
+	Criteria      []string
+	Sheet         string
+	Compression   string
+	// Overwrite allows replacing an existing file.
+	Overwrite bool
+	// Append adds to an existing CSV or NDJSON file, whose header or keys
+	// must match the fields written.
+	Append bool
+}
+
+type entry struct {
+	key   string
+	value interface{}
+}
+
+type record []entry
+
+func (r record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, e := range r {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(e.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(e.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func toRecords(data []models.Property, fields []resolvedField) []record {
+	if data == nil {
+		return nil
+	}
+
+	records := make([]record, len(data))
+	for i, property := range data {
+		records[i] = toRecord(property, fields)
+	}
+	return records
+}
+
+func toRecord(property models.Property, fields []resolvedField) record {
+	r := make(record, len(fields))
+	for i, field := range fields {
+		r[i] = entry{field.label, field.value(property)}
+	}
+	return r
+}
+
+func (o Options) ammenitiesKey() string {
+	if o.AmmenitiesKey == "" {
+		return models.AmmenitiesKey
+	}
+	return o.AmmenitiesKey
+}
@@ -0,0 +1,207 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+type report struct {
+	Title       string
+	GeneratedAt string
+	Criteria    []string
+	Count       int
+	PriceRange  string
+	Headers     []string
+	AlignRight  []bool
+	Rows        [][]string
+}
+
+func newReport(data []models.Property, options Options) (report, error) {
+	columns, err := resolveTableColumns(data, options)
+	if err != nil {
+		return report{}, err
+	}
+
+	r := report{
+		Title:       "Property Report",
+		GeneratedAt: time.Now().Format("2006-01-02 15:04"),
+		Criteria:    options.Criteria,
+		Count:       len(data),
+		PriceRange:  "-",
+	}
+
+	if len(data) > 0 {
+		minPrice, maxPrice := data[0].Price, data[0].Price
+		for _, property := range data {
+			minPrice = min(minPrice, property.Price)
+			maxPrice = max(maxPrice, property.Price)
+		}
+		r.PriceRange = formatMoney(minPrice) + " - " + formatMoney(maxPrice)
+	}
+
+	for _, column := range columns {
+		r.Headers = append(r.Headers, column.tableHeader())
+		r.AlignRight = append(r.AlignRight, column.alignRight)
+	}
+	for _, property := range data {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = column.display(property)
+		}
+		r.Rows = append(r.Rows, row)
+	}
+
+	return r, nil
+}
+
+func ToMarkdownFile(ctx context.Context, data []models.Property, path string, options Options) error {
+	return toFile(ctx, path, data, options, encodeMarkdown)
+}
+
+func WriteMarkdown(ctx context.Context, w io.Writer, data []models.Property, options Options) error {
+	return toWriter(ctx, w, data, options, encodeMarkdown)
+}
+
+func encodeMarkdown(data []models.Property, options Options) (func(io.Writer) error, error) {
+	r, err := newReport(data, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var md strings.Builder
+	fmt.Fprintf(&md, "# %s\n\n", r.Title)
+	fmt.Fprintf(&md, "Generated %s\n\n", r.GeneratedAt)
+	md.WriteString("## Summary\n\n")
+	fmt.Fprintf(&md, "- **Matches:** %d\n", r.Count)
+	fmt.Fprintf(&md, "- **Price range:** %s\n", r.PriceRange)
+	if len(r.Criteria) > 0 {
+		md.WriteString("- **Criteria:**\n")
+		for _, criterion := range r.Criteria {
+			fmt.Fprintf(&md, "  - `%s`\n", criterion)
+		}
+	} else {
+		md.WriteString("- **Criteria:** none\n")
+	}
+
+	md.WriteString("\n## Properties\n\n")
+	md.WriteString("| " + strings.Join(r.Headers, " | ") + " |\n")
+	for _, alignRight := range r.AlignRight {
+		if alignRight {
+			md.WriteString("| ---: ")
+		} else {
+			md.WriteString("| --- ")
+		}
+	}
+	md.WriteString("|\n")
+	for _, row := range r.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = escapeMarkdownCell(cell)
+		}
+		md.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	return func(w io.Writer) error {
+		if _, err := io.WriteString(w, md.String()); err != nil {
+			return fmt.Errorf("could not write Markdown report: %v", err)
+		}
+		return nil
+	}, nil
+}
+
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+var htmlReport = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #222; }
+  h1 { margin-bottom: 0.25rem; }
+  .generated { color: #777; margin-top: 0; }
+  .summary { display: flex; gap: 2rem; padding: 1rem; background: #f5f7fa; border-radius: 6px; }
+  .summary dt { font-size: 0.8rem; text-transform: uppercase; color: #777; }
+  .summary dd { margin: 0; font-size: 1.1rem; }
+  .summary code { display: block; }
+  table { border-collapse: collapse; width: 100%; margin-top: 1.5rem; font-size: 0.9rem; }
+  th, td { padding: 0.5rem 0.75rem; border-bottom: 1px solid #e3e6ea; text-align: left; vertical-align: top; }
+  th { background: #2f3b4c; color: #fff; cursor: pointer; user-select: none; white-space: nowrap; }
+  th.asc::after { content: " \25B2"; }
+  th.desc::after { content: " \25BC"; }
+  tr:nth-child(even) td { background: #fafbfc; }
+  .num { text-align: right; white-space: nowrap; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="generated">Generated {{.GeneratedAt}}</p>
+<dl class="summary">
+  <div><dt>Matches</dt><dd>{{.Count}}</dd></div>
+  <div><dt>Price range</dt><dd>{{.PriceRange}}</dd></div>
+  <div><dt>Criteria</dt><dd>{{range .Criteria}}<code>{{.}}</code>{{else}}none{{end}}</dd></div>
+</dl>
+<table id="properties">
+<thead><tr>{{range $i, $header := .Headers}}<th{{if index $.AlignRight $i}} class="num"{{end}}>{{$header}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr>{{range $i, $cell := .}}<td{{if index $.AlignRight $i}} class="num"{{end}}>{{$cell}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+<script>
+document.querySelectorAll("#properties th").forEach(function (header, column) {
+  header.addEventListener("click", function () {
+    var body = document.querySelector("#properties tbody");
+    var ascending = !header.classList.contains("asc");
+    document.querySelectorAll("#properties th").forEach(function (h) { h.classList.remove("asc", "desc"); });
+    header.classList.add(ascending ? "asc" : "desc");
+    var numeric = header.classList.contains("num");
+    var value = function (row) {
+      var text = row.children[column].textContent;
+      var number = parseFloat(text.replace(/[^0-9.\-]/g, ""));
+      return numeric && !isNaN(number) ? number : text.toLowerCase();
+    };
+    Array.from(body.rows)
+      .sort(function (a, b) {
+        var x = value(a), y = value(b);
+        return (x < y ? -1 : x > y ? 1 : 0) * (ascending ? 1 : -1);
+      })
+      .forEach(function (row) { body.appendChild(row); });
+  });
+});
+</script>
+</body>
+</html>
+`))
+
+func ToHTMLFile(ctx context.Context, data []models.Property, path string, options Options) error {
+	return toFile(ctx, path, data, options, encodeHTML)
+}
+
+func WriteHTML(ctx context.Context, w io.Writer, data []models.Property, options Options) error {
+	return toWriter(ctx, w, data, options, encodeHTML)
+}
+
+func encodeHTML(data []models.Property, options Options) (func(io.Writer) error, error) {
+	r, err := newReport(data, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer) error {
+		if err := htmlReport.Execute(w, r); err != nil {
+			return fmt.Errorf("could not write HTML report: %v", err)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,59 @@
+package output
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestToMarkdownFile(t *testing.T) {
+	data := []models.Property{
+		{Price: 250000, Description: "Home | garden"},
+		{Price: 180000, Description: "Loft"},
+	}
+	path := filepath.Join(t.TempDir(), "report.md")
+
+	err := ToMarkdownFile(context.Background(), data, path, Options{Fields: []Field{{Key: "price"}, {Key: "description"}}, Criteria: []string{"--price lt 300000"}})
+	if err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"- **Matches:** 2",
+		"- **Price range:** $180,000.00 - $250,000.00",
+		"  - `--price lt 300000`",
+		"| PRICE | DESCRIPTION |",
+		"| ---: | --- |",
+		`| $250,000.00 | Home \| garden |`,
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("expected report to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
+
+func TestToHTMLFileEscapes(t *testing.T) {
+	data := []models.Property{{Description: "<script>alert(1)</script>"}}
+	path := filepath.Join(t.TempDir(), "report.html")
+
+	if err := ToHTMLFile(context.Background(), data, path, Options{Fields: []Field{{Key: "description"}}}); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "<script>alert(1)</script>") {
+		t.Errorf("expected description to be escaped")
+	}
+}
@@ -0,0 +1,154 @@
+package output
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+	_ "modernc.org/sqlite"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS properties (
+	key           TEXT PRIMARY KEY,
+	squareFootage REAL NOT NULL,
+	lighting      TEXT NOT NULL,
+	price         REAL NOT NULL,
+	rooms         REAL NOT NULL,
+	bathrooms     REAL NOT NULL,
+	latitude      REAL NOT NULL,
+	longitude     REAL NOT NULL,
+	description   TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS property_amenities (
+	propertyKey TEXT NOT NULL REFERENCES properties(key) ON DELETE CASCADE,
+	name        TEXT NOT NULL,
+	present     INTEGER NOT NULL,
+	PRIMARY KEY (propertyKey, name)
+);
+`
+
+const upsertProperty = `
+INSERT INTO properties (key, squareFootage, lighting, price, rooms, bathrooms, latitude, longitude, description)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (key) DO UPDATE SET
+	squareFootage = excluded.squareFootage,
+	lighting = excluded.lighting,
+	price = excluded.price,
+	rooms = excluded.rooms,
+	bathrooms = excluded.bathrooms,
+	latitude = excluded.latitude,
+	longitude = excluded.longitude,
+	description = excluded.description
+`
+
+// ToSQLiteFile upserts data into the properties and property_amenities tables
+// of the database at path, creating them if needed. Writing the same
+// properties again updates their rows instead of duplicating them. The rows
+// are written in one transaction, so an existing database is left as it was
+// when writing fails or ctx is done, and a new one is removed.
+func ToSQLiteFile(ctx context.Context, data []models.Property, path string, options Options) error {
+	if options.Compression != "" {
+		return fmt.Errorf("SQLite output can't be compressed")
+	}
+
+	_, statErr := os.Stat(path)
+	if err := writeSQLite(ctx, data, path); err != nil {
+		if os.IsNotExist(statErr) {
+			os.Remove(path)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func writeSQLite(ctx context.Context, data []models.Property, path string) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("could not open database: %v", err)
+	}
+	defer db.Close()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, sqliteSchema); err != nil {
+		return fmt.Errorf("could not create tables: %v", err)
+	}
+
+	insertProperty, err := tx.PrepareContext(ctx, upsertProperty)
+	if err != nil {
+		return fmt.Errorf("could not prepare property insert: %v", err)
+	}
+	defer insertProperty.Close()
+
+	deleteAmmenities, err := tx.PrepareContext(ctx, `DELETE FROM property_amenities WHERE propertyKey = ?`)
+	if err != nil {
+		return fmt.Errorf("could not prepare amenities delete: %v", err)
+	}
+	defer deleteAmmenities.Close()
+
+	insertAmmenity, err := tx.PrepareContext(ctx, `INSERT INTO property_amenities (propertyKey, name, present) VALUES (?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("could not prepare amenities insert: %v", err)
+	}
+	defer insertAmmenity.Close()
+
+	for _, property := range data {
+		key := propertyKey(property)
+
+		_, err := insertProperty.ExecContext(ctx, key, property.SquareFootage, property.Lighting, property.Price, property.Rooms,
+			property.Bathrooms, property.Location[0], property.Location[1], property.Description)
+		if err != nil {
+			return fmt.Errorf("could not write property: %v", err)
+		}
+
+		if _, err := deleteAmmenities.ExecContext(ctx, key); err != nil {
+			return fmt.Errorf("could not clear amenities: %v", err)
+		}
+		for name, present := range property.Ammenities {
+			if _, err := insertAmmenity.ExecContext(ctx, key, name, present); err != nil {
+				return fmt.Errorf("could not write amenity %s: %v", name, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %v", err)
+	}
+
+	return nil
+}
+
+// propertyKey identifies a property by every field that's stored, so writing
+// the same property again updates its own row whatever else is written with
+// it, and a changed property gets a new row.
+func propertyKey(property models.Property) string {
+	hash := sha256.New()
+	for _, value := range []float64{property.SquareFootage, property.Price, property.Rooms, property.Bathrooms, property.Location[0], property.Location[1]} {
+		hash.Write([]byte(strconv.FormatFloat(value, 'g', -1, 64)))
+		hash.Write([]byte{0})
+	}
+	for _, text := range []string{property.Lighting, property.Description} {
+		hash.Write([]byte(text))
+		hash.Write([]byte{0})
+	}
+	for _, name := range slices.Sorted(maps.Keys(property.Ammenities)) {
+		hash.Write([]byte(name))
+		hash.Write([]byte{0})
+		hash.Write([]byte(strconv.FormatBool(property.Ammenities[name])))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
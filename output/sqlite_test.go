@@ -0,0 +1,88 @@
+package output
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestToSQLiteFile(t *testing.T) {
+	data := []models.Property{
+		{SquareFootage: 1200, Lighting: "high", Price: 250000, Rooms: 3, Bathrooms: 2, Location: [2]float64{34.05, -118.24}, Description: "Bright loft", Ammenities: map[string]bool{"pool": true, "garage": false}},
+		{SquareFootage: 800, Lighting: "low", Price: 90000, Rooms: 1, Bathrooms: 1, Location: [2]float64{40.71, -74.0}, Description: "Studio", Ammenities: map[string]bool{"yard": true}},
+	}
+
+	path := filepath.Join(t.TempDir(), "out.sqlite")
+	if err := ToSQLiteFile(context.Background(), data, path, Options{}); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	if err := ToSQLiteFile(context.Background(), data, path, Options{}); err != nil {
+		t.Fatalf("did not expect error on rerun but got: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var properties, ammenities int
+	if err := db.QueryRow(`SELECT count(*) FROM properties`).Scan(&properties); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueryRow(`SELECT count(*) FROM property_amenities`).Scan(&ammenities); err != nil {
+		t.Fatal(err)
+	}
+	if properties != 2 || ammenities != 3 {
+		t.Errorf("expected 2 properties and 3 amenities, got %d and %d", properties, ammenities)
+	}
+
+	var present bool
+	if err := db.QueryRow(`SELECT a.present FROM property_amenities a JOIN properties p ON p.key = a.propertyKey WHERE p.description = 'Bright loft' AND a.name = 'pool'`).Scan(&present); err != nil || !present {
+		t.Errorf("expected the property to keep its pool amenity, got %v, %v", present, err)
+	}
+}
+
+func TestToSQLiteFileSameLayout(t *testing.T) {
+	unitA := models.Property{SquareFootage: 900, Lighting: "medium", Price: 120000, Rooms: 2, Bathrooms: 1, Location: [2]float64{34.05, -118.24}, Description: "Unit A", Ammenities: map[string]bool{"pool": true}}
+	unitB := unitA
+	unitB.Price = 180000
+	unitB.Description = "Unit B"
+	unitB.Ammenities = map[string]bool{"gym": true}
+
+	path := filepath.Join(t.TempDir(), "out.sqlite")
+	for _, data := range [][]models.Property{{unitA, unitB}, {unitB}} {
+		if err := ToSQLiteFile(context.Background(), data, path, Options{}); err != nil {
+			t.Fatalf("did not expect error but got: %v", err)
+		}
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT p.description, a.name FROM properties p JOIN property_amenities a ON a.propertyKey = p.key ORDER BY p.description`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var result [][2]string
+	for rows.Next() {
+		var description, ammenity string
+		if err := rows.Scan(&description, &ammenity); err != nil {
+			t.Fatal(err)
+		}
+		result = append(result, [2]string{description, ammenity})
+	}
+	if expected := [][2]string{{"Unit A", "pool"}, {"Unit B", "gym"}}; !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
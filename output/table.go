@@ -0,0 +1,164 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+	"golang.org/x/term"
+)
+
+const (
+	defaultTableWidth   = 120
+	minDescriptionWidth = 20
+	columnGap           = "  "
+	ansiBold            = "\033[1m"
+	ansiDim             = "\033[2m"
+	ansiReset           = "\033[0m"
+)
+
+var tableColumns = []string{"price", "sqft", "rooms", "bathrooms", "lighting", "location", "amenities", "description", "score"}
+
+func ToTableStdOut(ctx context.Context, data []models.Property, options Options) error {
+	fd := int(os.Stdout.Fd())
+	isTerminal := term.IsTerminal(fd)
+
+	width := defaultTableWidth
+	if isTerminal {
+		if terminalWidth, _, err := term.GetSize(fd); err == nil && terminalWidth > 0 {
+			width = terminalWidth
+		}
+	} else if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		width = columns
+	}
+
+	color := isTerminal && os.Getenv("NO_COLOR") == ""
+
+	return writeTable(withContext(ctx, os.Stdout), data, options, width, color)
+}
+
+func writeTable(w io.Writer, data []models.Property, options Options, width int, color bool) error {
+	columns, err := resolveTableColumns(data, options)
+	if err != nil {
+		return err
+	}
+
+	cells := make([][]string, len(data))
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		widths[i] = utf8.RuneCountInString(tableCell(column.tableHeader()))
+	}
+	for row, property := range data {
+		cells[row] = make([]string, len(columns))
+		for i, column := range columns {
+			cells[row][i] = tableCell(column.display(property))
+			widths[i] = max(widths[i], utf8.RuneCountInString(cells[row][i]))
+		}
+	}
+
+	for i, column := range columns {
+		if column.name != "description" {
+			continue
+		}
+		used := 0
+		for j := range widths {
+			if j != i {
+				used += widths[j] + len(columnGap)
+			}
+		}
+		widths[i] = min(widths[i], max(width-used, minDescriptionWidth))
+	}
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = tableCell(column.tableHeader())
+	}
+
+	if err := writeTableRow(w, columns, widths, headers, color, ansiBold); err != nil {
+		return err
+	}
+	for row := range cells {
+		style := ""
+		if row%2 == 1 {
+			style = ansiDim
+		}
+		if err := writeTableRow(w, columns, widths, cells[row], color, style); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeTableRow(w io.Writer, columns []resolvedField, widths []int, cells []string, color bool, style string) error {
+	var line strings.Builder
+	for i, cell := range cells {
+		if i > 0 {
+			line.WriteString(columnGap)
+		}
+
+		cell = truncate(cell, widths[i])
+		padding := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		if columns[i].alignRight {
+			line.WriteString(padding + cell)
+		} else if i < len(cells)-1 {
+			line.WriteString(cell + padding)
+		} else {
+			line.WriteString(cell)
+		}
+	}
+
+	text := line.String()
+	if color && style != "" {
+		text = style + text + ansiReset
+	}
+
+	if _, err := fmt.Fprintln(w, text); err != nil {
+		return fmt.Errorf("could not write table row: %v", err)
+	}
+
+	return nil
+}
+
+// tableCell replaces newlines, tabs and other control characters with spaces,
+// so a value stays on its row and its width can be measured.
+func tableCell(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string([]rune(s)[:width])
+	}
+	return string([]rune(s)[:width-1]) + "…"
+}
+
+// resolveTableColumns resolves the --columns list of tables and reports, or
+// their fields when no columns are given.
+func resolveTableColumns(data []models.Property, options Options) ([]resolvedField, error) {
+	if len(options.Columns) > 0 {
+		options.Fields = nil
+		for _, key := range options.Columns {
+			if !slices.Contains(tableColumns, key) {
+				return nil, fmt.Errorf("invalid table column: %s", key)
+			}
+			options.Fields = append(options.Fields, Field{Key: key})
+		}
+	}
+	return resolveFields(data, options, defaultTableFields)
+}
@@ -0,0 +1,104 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestWriteTable(t *testing.T) {
+	data := []models.Property{
+		{Price: 1250000, SquareFootage: 2000, Description: "A very long description that will not fit"},
+		{Price: 90000, SquareFootage: 500, Description: "Short"},
+	}
+
+	var buf bytes.Buffer
+	err := writeTable(&buf, data, Options{Columns: []string{"price", "sqft", "description"}}, 40, false)
+	if err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"        PRICE   SQFT  DESCRIPTION",
+		"$1,250,000.00  2,000  A very long descrip…",
+		"   $90,000.00    500  Short",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteTableColor(t *testing.T) {
+	data := []models.Property{{Price: 1}}
+
+	var buf bytes.Buffer
+	if err := writeTable(&buf, data, Options{Columns: []string{"price"}}, 80, true); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), ansiBold) {
+		t.Errorf("expected colored header, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeTable(&buf, data, Options{Columns: []string{"price"}}, 80, false); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+	if strings.Contains(buf.String(), "\033[") {
+		t.Errorf("expected no escape codes, got %q", buf.String())
+	}
+}
+
+func TestWriteTableControlCharacters(t *testing.T) {
+	data := []models.Property{{Price: 1, Description: "line one\nline two\tend"}}
+
+	var buf bytes.Buffer
+	if err := writeTable(&buf, data, Options{Columns: []string{"price", "description"}}, 80, false); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"PRICE  DESCRIPTION",
+		"$1.00  line one line two end",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteTableScore(t *testing.T) {
+	score := 1.5
+	data := []models.Property{{Price: 1, Rooms: 2, Lighting: "low", Score: &score}}
+
+	var buf bytes.Buffer
+	if err := writeTable(&buf, data, Options{}, 120, false); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+	if header := strings.Fields(buf.String()); header[0] != "SCORE" || header[1] != "PRICE" {
+		t.Errorf("expected score to be the first column, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeTable(&buf, data, Options{Fields: []Field{{Key: "rooms", Label: "Rooms"}, {Key: "price"}}}, 120, false); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+	if header := strings.Fields(buf.String()); header[0] != "Rooms" || header[1] != "PRICE" {
+		t.Errorf("expected fields to set the columns, got %q", buf.String())
+	}
+}
+
+func TestWriteTableInvalidColumn(t *testing.T) {
+	for _, options := range []Options{
+		{Columns: []string{"garden"}},
+		{Columns: []string{"latitude"}},
+		{Fields: []Field{{Key: "garden"}}},
+	} {
+		var buf bytes.Buffer
+		if err := writeTable(&buf, nil, options, 80, false); err == nil {
+			t.Errorf("expected error for %+v but got nil", options)
+		}
+	}
+}
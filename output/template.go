@@ -0,0 +1,61 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/ramirofarias/prop-filter-cli/filter"
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+var templateFuncs = template.FuncMap{
+	"formatMoney": formatMoney,
+	"number":      formatPlain,
+	"round": func(value float64, places int) string {
+		scale := math.Pow(10, float64(places))
+		return formatPlain(math.Round(value*scale) / scale)
+	},
+	"join": func(separator string, items interface{}) (string, error) {
+		switch items := items.(type) {
+		case []string:
+			return strings.Join(items, separator), nil
+		case map[string]bool:
+			return strings.Join(availableAmmenities(items), separator), nil
+		default:
+			return "", fmt.Errorf("join: unsupported type %T", items)
+		}
+	},
+	"distance": func(location [2]float64, lat, long float64) float64 {
+		return filter.Distance(lat, long, location[0], location[1])
+	},
+}
+
+func ParseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse template: %v", err)
+	}
+	return tmpl, nil
+}
+
+func ToTemplateFile(ctx context.Context, data []models.Property, path string, tmpl *template.Template, options Options) error {
+	return WriteFile(ctx, path, options, func(w io.Writer) error {
+		return WriteTemplate(ctx, w, data, tmpl)
+	})
+}
+
+func ToTemplateStdOut(ctx context.Context, data []models.Property, tmpl *template.Template) error {
+	return WriteTemplate(ctx, os.Stdout, data, tmpl)
+}
+
+func WriteTemplate(ctx context.Context, w io.Writer, data []models.Property, tmpl *template.Template) error {
+	if err := tmpl.Execute(withContext(ctx, w), data); err != nil {
+		return fmt.Errorf("could not render template: %v", err)
+	}
+	return nil
+}
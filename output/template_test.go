@@ -0,0 +1,83 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestWriteTemplate(t *testing.T) {
+	data := []models.Property{
+		{
+			Price:       1250000,
+			Rooms:       3,
+			Location:    [2]float64{-34.6037, -58.3816},
+			Description: "Loft",
+			Ammenities:  map[string]bool{"pool": true, "garage": true, "yard": false},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		template  string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "formatMoney",
+			template: `{{range .}}{{formatMoney .Price}}{{end}}`,
+			expected: "$1,250,000.00",
+		},
+		{
+			name:     "join amenities",
+			template: `{{range .}}{{join ", " .Ammenities}}{{end}}`,
+			expected: "garage, pool",
+		},
+		{
+			name:     "round distance",
+			template: `{{range .}}{{round (distance .Location -34.6037 -58.3816) 2}}{{end}}`,
+			expected: "0",
+		},
+		{
+			name:     "SQL insert",
+			template: `{{range .}}INSERT INTO listings (price, rooms) VALUES ({{number .Price}}, {{number .Rooms}});{{end}}`,
+			expected: "INSERT INTO listings (price, rooms) VALUES (1250000, 3);",
+		},
+		{
+			name:     "round large number",
+			template: `{{range .}}{{round .Price -3}} {{round 1234.5678 2}}{{end}}`,
+			expected: "1250000 1234.57",
+		},
+		{
+			name:      "join unsupported type",
+			template:  `{{range .}}{{join ", " .Price}}{{end}}`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(tt.name, tt.template)
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+
+			var buf bytes.Buffer
+			err = WriteTemplate(context.Background(), &buf, data, tmpl)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("did not expect error but got: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+	"github.com/xuri/excelize/v2"
+)
+
+const defaultSheet = "Properties"
+
+func ToXLSXFile(ctx context.Context, data []models.Property, path string, options Options) error {
+	return toFile(ctx, path, data, options, encodeXLSX)
+}
+
+func WriteXLSX(ctx context.Context, w io.Writer, data []models.Property, options Options) error {
+	return toWriter(ctx, w, data, options, encodeXLSX)
+}
+
+func encodeXLSX(data []models.Property, options Options) (func(io.Writer) error, error) {
+	fields, err := resolveFields(data, options, defaultCSVFields)
+	if err != nil {
+		return nil, err
+	}
+	fields = expandAmmenities(data, fields)
+
+	sheet := options.Sheet
+	if sheet == "" {
+		sheet = defaultSheet
+	}
+
+	file := excelize.NewFile()
+	defer file.Close()
+
+	if err := file.SetSheetName(file.GetSheetName(0), sheet); err != nil {
+		return nil, fmt.Errorf("invalid sheet name: %v", err)
+	}
+
+	header := make([]interface{}, len(fields))
+	for i, field := range fields {
+		header[i] = field.label
+	}
+	if err := file.SetSheetRow(sheet, "A1", &header); err != nil {
+		return nil, fmt.Errorf("error writing XLSX header: %v", err)
+	}
+
+	for row, property := range data {
+		values := make([]interface{}, len(fields))
+		for i, field := range fields {
+			values[i] = xlsxValue(field, property)
+		}
+
+		cell, _ := excelize.CoordinatesToCellName(1, row+2)
+		if err := file.SetSheetRow(sheet, cell, &values); err != nil {
+			return nil, fmt.Errorf("error writing XLSX row: %v", err)
+		}
+	}
+
+	if err := styleXLSXHeader(file, sheet, len(fields)); err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer) error {
+		if err := file.Write(w); err != nil {
+			return fmt.Errorf("could not write XLSX file: %v", err)
+		}
+		return nil
+	}, nil
+}
+
+// expandAmmenities replaces the amenities field with one column per amenity
+// found in data, in alphabetical order.
+func expandAmmenities(data []models.Property, fields []resolvedField) []resolvedField {
+	var expanded []resolvedField
+	for _, field := range fields {
+		if !field.expandsAmmenities {
+			expanded = append(expanded, field)
+			continue
+		}
+
+		names := map[string]bool{}
+		for _, property := range data {
+			for name := range property.Ammenities {
+				names[name] = true
+			}
+		}
+		sorted := make([]string, 0, len(names))
+		for name := range names {
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+
+		for _, name := range sorted {
+			expanded = append(expanded, resolvedField{fieldDefinition: ammenityField(name), label: name})
+		}
+	}
+	return expanded
+}
+
+// Numbers, booleans and text keep their cell type, anything else is written
+// as its CSV text.
+func xlsxValue(field resolvedField, property models.Property) interface{} {
+	switch value := field.value(property).(type) {
+	case float64, bool, string, nil:
+		return value
+	default:
+		return field.text(property)
+	}
+}
+
+func styleXLSXHeader(file *excelize.File, sheet string, columns int) error {
+	style, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return fmt.Errorf("error creating XLSX header style: %v", err)
+	}
+
+	if columns > 0 {
+		lastCell, _ := excelize.CoordinatesToCellName(columns, 1)
+		if err := file.SetCellStyle(sheet, "A1", lastCell, style); err != nil {
+			return fmt.Errorf("error styling XLSX header: %v", err)
+		}
+	}
+
+	err = file.SetPanes(sheet, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	})
+	if err != nil {
+		return fmt.Errorf("error freezing XLSX header: %v", err)
+	}
+
+	return nil
+}
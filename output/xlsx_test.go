@@ -0,0 +1,72 @@
+package output
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestToXLSXFile(t *testing.T) {
+	data := []models.Property{
+		{SquareFootage: 1200, Lighting: "high", Price: 250000.5, Rooms: 3, Bathrooms: 2, Location: [2]float64{34.05, -118.24}, Description: "Bright loft", Ammenities: map[string]bool{"pool": true, "garage": false}},
+		{SquareFootage: 800, Lighting: "low", Price: 90000, Rooms: 1, Bathrooms: 1, Location: [2]float64{40.71, -74.0}, Description: "Studio", Ammenities: map[string]bool{"yard": true}},
+	}
+
+	path := filepath.Join(t.TempDir(), "out.xlsx")
+	if err := ToXLSXFile(context.Background(), data, path, Options{}); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	file, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(defaultSheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedHeader := []string{"squareFootage", "lighting", "price", "rooms", "bathrooms", "latitude", "longitude", "description", "garage", "pool", "yard"}
+	if !reflect.DeepEqual(rows[0], expectedHeader) {
+		t.Errorf("expected header %v, got %v", expectedHeader, rows[0])
+	}
+
+	// Numeric cells are written without a type attribute, which excelize reports as unset.
+	for cell, expected := range map[string]excelize.CellType{"C2": excelize.CellTypeUnset, "B2": excelize.CellTypeSharedString, "J2": excelize.CellTypeBool} {
+		cellType, err := file.GetCellType(defaultSheet, cell)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cellType != expected {
+			t.Errorf("expected %s to have type %v, got %v", cell, expected, cellType)
+		}
+	}
+
+	if value, _ := file.GetCellValue(defaultSheet, "C2", excelize.Options{RawCellValue: true}); value != "250000.5" {
+		t.Errorf("expected C2 to hold 250000.5, got %q", value)
+	}
+	if value, _ := file.GetCellValue(defaultSheet, "I3"); value != "" {
+		t.Errorf("expected missing amenity to be empty, got %q", value)
+	}
+
+	panes, err := file.GetPanes(defaultSheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !panes.Freeze || panes.YSplit != 1 {
+		t.Errorf("expected frozen header row, got %+v", panes)
+	}
+
+	expectedRows := [][]string{
+		{"1200", "high", "250000.5", "3", "2", "34.05", "-118.24", "Bright loft", "FALSE", "TRUE"},
+		{"800", "low", "90000", "1", "1", "40.71", "-74", "Studio", "", "", "TRUE"},
+	}
+	if !reflect.DeepEqual(rows[1:], expectedRows) {
+		t.Errorf("expected rows %q, got %q", expectedRows, rows[1:])
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 func ParseComparison(s string) ([]filter.Comparison, error) {
-	operators := []string{"lte", "gte", "eq", "lt", "gt", "in"}
+	operators := []string{"lte", "gte", "eq", "ne", "lt", "gt", "in", "between", "oneof"}
 	trimmedString := strings.TrimSpace(s)
 	var comparisons []filter.Comparison
 
@@ -24,6 +24,24 @@ func ParseComparison(s string) ([]filter.Comparison, error) {
 				return comparisons, nil
 			}
 
+			if op == "between" {
+				intervalComparisons, err := parseIntervalComparison(trimmedString[len(op):])
+				if err != nil {
+					return nil, err
+				}
+				comparisons = append(comparisons, intervalComparisons...)
+				return comparisons, nil
+			}
+
+			if op == "oneof" {
+				setComparison, err := parseSetComparison(trimmedString[len(op):])
+				if err != nil {
+					return nil, err
+				}
+				comparisons = append(comparisons, setComparison)
+				return comparisons, nil
+			}
+
 			comp, err := parseSingleComparison(op, trimmedString[len(op):])
 			if err != nil {
 				return nil, err
@@ -59,15 +77,107 @@ func parseRangeComparison(valueRange string) ([]filter.Comparison, error) {
 	}, nil
 }
 
+func parseIntervalComparison(interval string) ([]filter.Comparison, error) {
+	trimmedInterval := strings.TrimSpace(interval)
+	if len(trimmedInterval) < 2 {
+		return nil, fmt.Errorf("interval comparison requires brackets, got: %s", trimmedInterval)
+	}
+
+	var lowerOperator, upperOperator string
+	switch trimmedInterval[0] {
+	case '(':
+		lowerOperator = "gt"
+	case '[':
+		lowerOperator = "gte"
+	default:
+		return nil, fmt.Errorf("interval must start with ( or [, got: %s", trimmedInterval)
+	}
+	switch trimmedInterval[len(trimmedInterval)-1] {
+	case ')':
+		upperOperator = "lt"
+	case ']':
+		upperOperator = "lte"
+	default:
+		return nil, fmt.Errorf("interval must end with ) or ], got: %s", trimmedInterval)
+	}
+
+	values := strings.Split(trimmedInterval[1:len(trimmedInterval)-1], ",")
+	if len(values) != 2 {
+		return nil, fmt.Errorf("interval comparison requires two values, got: %s", trimmedInterval)
+	}
+
+	lower, err := strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number in interval: %s", values[0])
+	}
+
+	upper, err := strconv.ParseFloat(strings.TrimSpace(values[1]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number in interval: %s", values[1])
+	}
+
+	if lower > upper {
+		return nil, fmt.Errorf("interval lower bound is greater than upper bound: %s", trimmedInterval)
+	}
+
+	return []filter.Comparison{
+		{Value: lower, Operator: lowerOperator},
+		{Value: upper, Operator: upperOperator},
+	}, nil
+}
+
+func parseSetComparison(set string) (filter.Comparison, error) {
+	trimmedSet := strings.TrimSpace(set)
+	if trimmedSet == "" {
+		return filter.Comparison{}, fmt.Errorf("set comparison requires at least one value")
+	}
+
+	var values []float64
+	for _, value := range strings.Split(trimmedSet, ",") {
+		num, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return filter.Comparison{}, fmt.Errorf("invalid number in set: %s", value)
+		}
+		values = append(values, num)
+	}
+
+	return filter.Comparison{
+		Values:   values,
+		Operator: "oneof",
+	}, nil
+}
+
 func parseSingleComparison(operator, valueStr string) (filter.Comparison, error) {
-	numStr := strings.TrimSpace(valueStr)
+	numStr, toleranceStr, hasTolerance := cutTolerance(strings.TrimSpace(valueStr))
 	num, err := strconv.ParseFloat(numStr, 64)
 	if err != nil {
 		return filter.Comparison{}, fmt.Errorf("invalid number in comparison: %s", numStr)
 	}
 
-	return filter.Comparison{
+	comparison := filter.Comparison{
 		Value:    num,
 		Operator: operator,
-	}, nil
+	}
+
+	if hasTolerance {
+		if operator != "eq" && operator != "ne" {
+			return filter.Comparison{}, fmt.Errorf("tolerance is only supported by eq and ne, got: %s", operator)
+		}
+		comparison.HasTolerance = true
+		comparison.Tolerance, err = strconv.ParseFloat(toleranceStr, 64)
+		if err != nil || comparison.Tolerance < 0 {
+			return filter.Comparison{}, fmt.Errorf("invalid tolerance in comparison: %s", toleranceStr)
+		}
+	}
+
+	return comparison, nil
+}
+
+func cutTolerance(s string) (value string, tolerance string, found bool) {
+	for _, separator := range []string{"±", "+-"} {
+		if before, after, found := strings.Cut(s, separator); found {
+			return strings.TrimSpace(before), strings.TrimSpace(after), true
+		}
+	}
+	return s, "", false
 }
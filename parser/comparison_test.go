@@ -34,6 +34,66 @@ func TestParseComparison(t *testing.T) {
 			input:    "in 2.5, 5.5",
 			expected: []filter.Comparison{{Operator: "gte", Value: 2.5}, {Operator: "lte", Value: 5.5}},
 		},
+		{
+			name:     "Valid ne comparison",
+			input:    "ne 3",
+			expected: []filter.Comparison{{Operator: "ne", Value: 3}},
+		},
+		{
+			name:     "Valid open interval",
+			input:    "between (2,5)",
+			expected: []filter.Comparison{{Operator: "gt", Value: 2}, {Operator: "lt", Value: 5}},
+		},
+		{
+			name:     "Valid half-open interval",
+			input:    "between [2, 5)",
+			expected: []filter.Comparison{{Operator: "gte", Value: 2}, {Operator: "lt", Value: 5}},
+		},
+		{
+			name:     "Valid set comparison",
+			input:    "oneof 1, 3,5",
+			expected: []filter.Comparison{{Operator: "oneof", Values: []float64{1, 3, 5}}},
+		},
+		{
+			name:     "Valid eq comparison with tolerance",
+			input:    "eq 250000 ±0.01",
+			expected: []filter.Comparison{{Operator: "eq", Value: 250000, Tolerance: 0.01, HasTolerance: true}},
+		},
+		{
+			name:     "Valid eq comparison with ascii tolerance",
+			input:    "eq 250000+-0.5",
+			expected: []filter.Comparison{{Operator: "eq", Value: 250000, Tolerance: 0.5, HasTolerance: true}},
+		},
+		{
+			name:     "Valid eq comparison with zero tolerance",
+			input:    "eq 250000 ±0",
+			expected: []filter.Comparison{{Operator: "eq", Value: 250000, HasTolerance: true}},
+		},
+		{
+			name:      "Tolerance on unsupported operator",
+			input:     "gt 3 ±1",
+			expectErr: true,
+		},
+		{
+			name:      "Invalid tolerance",
+			input:     "eq 3 ±abc",
+			expectErr: true,
+		},
+		{
+			name:      "Interval without brackets",
+			input:     "between 2,5",
+			expectErr: true,
+		},
+		{
+			name:      "Interval with reversed bounds",
+			input:     "between [5,2]",
+			expectErr: true,
+		},
+		{
+			name:      "Set with non-numeric value",
+			input:     "oneof 1,a",
+			expectErr: true,
+		},
 		{
 			name:      "Invalid operator",
 			input:     "asd 3",
@@ -1,19 +1,23 @@
 package parser
 
 import (
-	"fmt"
 	"strings"
+
+	"github.com/ramirofarias/prop-filter-cli/output"
 )
 
-func ParseFiletype(s string) (string, error) {
-	ext := strings.ToLower(s[strings.LastIndex(s, ".")+1:])
-	if ext == "" {
-		return "", fmt.Errorf("output file must have an extension (e.g., .json, .csv)")
-	}
+var compressionExtensions = map[string]string{
+	"gz":   output.CompressionGzip,
+	"gzip": output.CompressionGzip,
+	"zst":  output.CompressionZstd,
+	"zstd": output.CompressionZstd,
+}
 
-	if ext != "json" && ext != "csv" {
-		return "", fmt.Errorf("invalid output type: %s", ext)
+// ParseCompression returns the compression implied by a path's extension, or
+// an empty string if it has none.
+func ParseCompression(s string) string {
+	if !strings.Contains(s, ".") {
+		return ""
 	}
-
-	return ext, nil
+	return compressionExtensions[strings.ToLower(s[strings.LastIndex(s, ".")+1:])]
 }
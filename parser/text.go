@@ -1,6 +1,13 @@
 package parser
 
-import "strings"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
 
 func ParseText(s string) []string {
 	s = strings.ToLower(s)
@@ -10,3 +17,85 @@ func ParseText(s string) []string {
 	}
 	return words
 }
+
+func ParseAmmenities(s string) (required []string, excluded []string) {
+	for _, word := range ParseText(s) {
+		if strings.HasPrefix(word, "!") {
+			excluded = append(excluded, strings.TrimSpace(word[1:]))
+		} else {
+			required = append(required, word)
+		}
+	}
+	return required, excluded
+}
+
+var lightingWord = regexp.MustCompile(`[a-z]+`)
+
+func ParseLighting(s string) ([]string, error) {
+	trimmedString := strings.ToLower(strings.TrimSpace(s))
+	if trimmedString == "" {
+		return nil, fmt.Errorf("lighting value can't be empty")
+	}
+
+	fields := strings.Fields(trimmedString)
+	if _, isLevel := models.LightingRank(strings.TrimRight(fields[0], ",")); !isLevel && len(fields) > 1 {
+		// Levels are replaced by their rank word by word, so a malformed
+		// value like "lowmedium" is rejected instead of read as two levels.
+		var unknown string
+		values := lightingWord.ReplaceAllStringFunc(trimmedString[len(fields[0]):], func(word string) string {
+			rank, ok := models.LightingRank(word)
+			if !ok {
+				unknown = word
+				return word
+			}
+			return strconv.Itoa(rank)
+		})
+		if unknown != "" {
+			return nil, fmt.Errorf("invalid lighting value: %s, expected one of %v", unknown, models.LightingLevels)
+		}
+
+		comparisons, err := ParseComparison(fields[0] + values)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lighting comparison: %s", s)
+		}
+
+		var levels []string
+	Levels:
+		for rank, level := range models.LightingLevels {
+			for _, comparison := range comparisons {
+				matches, err := comparison.Matches(float64(rank))
+				if err != nil {
+					return nil, err
+				}
+				if !matches {
+					continue Levels
+				}
+			}
+			levels = append(levels, level)
+		}
+		if len(levels) == 0 {
+			return nil, fmt.Errorf("lighting comparison matches no lighting level: %s", s)
+		}
+		return levels, nil
+	}
+
+	levels := ParseText(trimmedString)
+	for _, level := range levels {
+		if _, ok := models.LightingRank(level); !ok {
+			return nil, fmt.Errorf("invalid lighting value: %s, expected one of %v", level, models.LightingLevels)
+		}
+	}
+	return levels, nil
+}
+
+// ParsePatterns splits a comma-separated list of glob patterns. Unlike
+// ParseText it keeps their case, since file names are case-sensitive.
+func ParsePatterns(s string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(s, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -37,3 +38,107 @@ func TestParseText(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "*.json, data/*North*",
+			expected: []string{"*.json", "data/*North*"},
+		},
+		{
+			input:    " *_draft* ,, ",
+			expected: []string{"*_draft*"},
+		},
+		{
+			input:    "",
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result := ParsePatterns(test.input)
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseAmmenities(t *testing.T) {
+	tests := []struct {
+		input            string
+		expectedRequired []string
+		expectedExcluded []string
+	}{
+		{
+			input:            "garage, yard",
+			expectedRequired: []string{"garage", "yard"},
+		},
+		{
+			input:            "garage,!pool, ! hoa",
+			expectedRequired: []string{"garage"},
+			expectedExcluded: []string{"pool", "hoa"},
+		},
+		{
+			input:            "!Pool",
+			expectedExcluded: []string{"pool"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			required, excluded := ParseAmmenities(test.input)
+			if !reflect.DeepEqual(required, test.expectedRequired) {
+				t.Errorf("expected required %v, got %v", test.expectedRequired, required)
+			}
+			if !reflect.DeepEqual(excluded, test.expectedExcluded) {
+				t.Errorf("expected excluded %v, got %v", test.expectedExcluded, excluded)
+			}
+		})
+	}
+}
+
+func TestParseLighting(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  []string
+		expectErr bool
+	}{
+		{input: "high", expected: []string{"high"}},
+		{input: "Low, MEDIUM", expected: []string{"low", "medium"}},
+		{input: "gte medium", expected: []string{"medium", "high"}},
+		{input: "lt High", expected: []string{"low", "medium"}},
+		{input: "in low,medium", expected: []string{"low", "medium"}},
+		{input: "ne medium", expected: []string{"low", "high"}},
+		{input: "oneof low,high", expected: []string{"low", "high"}},
+		{input: "gt high", expectErr: true},
+		{input: "bright", expectErr: true},
+		{input: "gte bright", expectErr: true},
+		{input: "gte lowmedium", expectErr: true},
+		{input: "in low,mediums", expectErr: true},
+		{input: "between [low, high)", expected: []string{"low", "medium"}},
+		{input: "", expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := ParseLighting(test.input)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("did not expect error but got: %v", err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package propfilter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ramirofarias/prop-filter-cli/input"
+	"github.com/ramirofarias/prop-filter-cli/output"
+)
+
+type fileReader struct {
+	ReaderFunc
+	readFile func(ctx context.Context, path string, options ReadOptions) ([]Property, error)
+}
+
+func (r fileReader) ReadFile(ctx context.Context, path string, options ReadOptions) ([]Property, error) {
+	return r.readFile(ctx, path, options)
+}
+
+type fileWriter struct {
+	WriterFunc
+	writeFile func(ctx context.Context, properties []Property, path string, options WriteOptions) error
+}
+
+func (w fileWriter) WriteFile(ctx context.Context, path string, properties []Property, options WriteOptions) error {
+	return w.writeFile(ctx, properties, path, options)
+}
+
+// Built-in writers also write files directly, so invalid options are reported
+// before the file is created.
+func builtinWriter(write func(context.Context, io.Writer, []Property, WriteOptions) error, writeFile func(context.Context, []Property, string, WriteOptions) error) Writer {
+	return fileWriter{WriterFunc(write), writeFile}
+}
+
+func init() {
+	RegisterReader("json", ReaderFunc(func(ctx context.Context, r io.Reader, options ReadOptions) ([]Property, error) {
+		return input.FromJSON(ctx, r)
+	}))
+	RegisterReader("csv", ReaderFunc(func(ctx context.Context, r io.Reader, options ReadOptions) ([]Property, error) {
+		return input.FromCSV(ctx, r)
+	}))
+	RegisterReader("geojson", ReaderFunc(func(ctx context.Context, r io.Reader, options ReadOptions) ([]Property, error) {
+		return input.FromGeoJSON(ctx, r)
+	}))
+	RegisterReader("xlsx", ReaderFunc(func(ctx context.Context, r io.Reader, options ReadOptions) ([]Property, error) {
+		return input.FromXLSX(ctx, r, options.Sheet)
+	}))
+	RegisterReader("parquet", fileReader{readParquet, func(ctx context.Context, path string, options ReadOptions) ([]Property, error) {
+		return input.FromParquetFile(ctx, path, options.Filter, options.Columns)
+	}})
+	sqliteReader := fileReader{readSQLite, func(ctx context.Context, path string, options ReadOptions) ([]Property, error) {
+		return input.FromSQLiteFile(ctx, path, options.SQLiteTable, options.SQLiteQuery)
+	}}
+	RegisterReader("sqlite", sqliteReader)
+	RegisterReader("db", sqliteReader)
+
+	RegisterWriter("json", builtinWriter(output.WriteJSON, output.ToJSONFile))
+	RegisterWriter("ndjson", builtinWriter(output.WriteNDJSON, output.ToNDJSONFile))
+	RegisterWriter("csv", builtinWriter(output.WriteCSV, output.ToCSVFile))
+	RegisterWriter("geojson", builtinWriter(output.WriteGeoJSON, output.ToGeoJSONFile))
+	RegisterWriter("kml", builtinWriter(output.WriteKML, output.ToKMLFile))
+	RegisterWriter("xlsx", builtinWriter(output.WriteXLSX, output.ToXLSXFile))
+	RegisterWriter("parquet", builtinWriter(output.WriteParquet, output.ToParquetFile))
+	RegisterWriter("md", builtinWriter(output.WriteMarkdown, output.ToMarkdownFile))
+	RegisterWriter("html", builtinWriter(output.WriteHTML, output.ToHTMLFile))
+	sqliteWriter := builtinWriter(writeSQLite, output.ToSQLiteFile)
+	RegisterWriter("sqlite", sqliteWriter)
+	RegisterWriter("db", sqliteWriter)
+}
+
+// Parquet needs random access, so streams are read into memory.
+func readParquet(ctx context.Context, r io.Reader, options ReadOptions) ([]Property, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Parquet data: %v", err)
+	}
+	return input.FromParquet(ctx, bytes.NewReader(content), int64(len(content)), options.Filter, options.Columns)
+}
+
+// SQLite databases are files, so streams go through a temporary one.
+func readSQLite(ctx context.Context, r io.Reader, options ReadOptions) ([]Property, error) {
+	file, err := os.CreateTemp("", "propfilter-*.sqlite")
+	if err != nil {
+		return nil, fmt.Errorf("could not create temporary database: %v", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if _, err := io.Copy(file, r); err != nil {
+		return nil, fmt.Errorf("error reading SQLite data: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("error reading SQLite data: %v", err)
+	}
+
+	return input.FromSQLiteFile(ctx, file.Name(), options.SQLiteTable, options.SQLiteQuery)
+}
+
+func writeSQLite(ctx context.Context, w io.Writer, properties []Property, options WriteOptions) error {
+	dir, err := os.MkdirTemp("", "propfilter-*")
+	if err != nil {
+		return fmt.Errorf("could not create temporary database: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "properties.sqlite")
+	if err := output.ToSQLiteFile(ctx, properties, path, options); err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not read temporary database: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(w, file); err != nil {
+		return fmt.Errorf("could not write SQLite data: %v", err)
+	}
+	return nil
+}
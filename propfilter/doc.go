@@ -0,0 +1,36 @@
+// Package propfilter reads, filters and writes property listings. It's the
+// library behind the prop-filter-cli command.
+//
+// Properties are read and written through Readers and Writers that work on
+// io.Reader and io.Writer, looked up by format name in a registry. The
+// built-in formats are json, ndjson (write only), csv, geojson, kml (write
+// only), xlsx, sqlite (also registered as db), parquet, md and html (write
+// only). Other formats can be added with RegisterReader and RegisterWriter,
+// after which ReadFile and WriteFile pick them up by file extension:
+//
+//	propfilter.RegisterWriter("tsv", propfilter.WriterFunc(writeTSV))
+//
+//	properties, err := propfilter.ReadFile(ctx, "listings.csv.gz", propfilter.ReadOptions{})
+//	if err != nil {
+//		return err
+//	}
+//
+//	matcher, err := propfilter.Compile(propfilter.Filter{
+//		Price: []propfilter.Comparison{{Operator: propfilter.OperatorLess, Value: 300000}},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//
+//	return propfilter.WriteFile(ctx, "cheap.tsv", matcher.Filter(properties), propfilter.WriteOptions{})
+//
+// File formats are detected from the extension, ignoring a trailing .gz or
+// .zst: compressed input is decompressed while it's read, and output is
+// compressed to match. Reading and writing stop once ctx is done. Output files
+// are written to a temporary file that's renamed into place once complete, and
+// an existing file is only replaced when WriteOptions.Overwrite is set.
+//
+// ListFiles, ReadFiles, Apply and ApplyIndex make up the pipeline the command
+// runs: list the input files, read them concurrently, then filter and rank
+// the properties with a Query.
+package propfilter
@@ -0,0 +1,84 @@
+package propfilter
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ramirofarias/prop-filter-cli/input"
+	"github.com/ramirofarias/prop-filter-cli/output"
+	"github.com/ramirofarias/prop-filter-cli/parser"
+)
+
+// Read reads properties in the given format from r.
+func Read(ctx context.Context, r io.Reader, format string, options ReadOptions) ([]Property, error) {
+	reader, ok := LookupReader(format)
+	if !ok {
+		return nil, fmt.Errorf("unsupported input format: %q", format)
+	}
+	return reader.Read(ctx, r, options)
+}
+
+// Write writes properties in the given format to w.
+func Write(ctx context.Context, w io.Writer, format string, properties []Property, options WriteOptions) error {
+	writer, ok := LookupWriter(format)
+	if !ok {
+		return fmt.Errorf("unsupported output format: %q", format)
+	}
+	return writer.Write(ctx, w, properties, options)
+}
+
+// ReadFile reads the properties in path, in the format given by its
+// extension, and sets their Source to path. Errors name the file.
+func ReadFile(ctx context.Context, path string, options ReadOptions) ([]Property, error) {
+	properties, err := readFile(ctx, path, options)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input file %s: %v", path, err)
+	}
+
+	for i := range properties {
+		properties[i].Source = path
+	}
+	return properties, nil
+}
+
+func readFile(ctx context.Context, path string, options ReadOptions) ([]Property, error) {
+	reader, ok := LookupReader(Format(path))
+	if !ok {
+		return nil, fmt.Errorf("unsupported input format: %q", Format(path))
+	}
+
+	if fileReader, ok := reader.(FileReader); ok {
+		return fileReader.ReadFile(ctx, path, options)
+	}
+
+	file, err := input.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return reader.Read(ctx, file, options)
+}
+
+// WriteFile writes properties to path, in the format given by its extension.
+// The file is compressed when options.Compression is set or the extension ends
+// in .gz or .zst.
+func WriteFile(ctx context.Context, path string, properties []Property, options WriteOptions) error {
+	writer, ok := LookupWriter(Format(path))
+	if !ok {
+		return fmt.Errorf("unsupported output format: %q", Format(path))
+	}
+
+	if options.Compression == "" {
+		options.Compression = parser.ParseCompression(path)
+	}
+
+	if fileWriter, ok := writer.(FileWriter); ok {
+		return fileWriter.WriteFile(ctx, path, properties, options)
+	}
+
+	return output.WriteFile(ctx, path, options, func(w io.Writer) error {
+		return writer.Write(ctx, w, properties, options)
+	})
+}
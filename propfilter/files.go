@@ -0,0 +1,154 @@
+package propfilter
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/ramirofarias/prop-filter-cli/input"
+	"golang.org/x/sync/errgroup"
+)
+
+// ListOptions select the input files listed by ListFiles.
+type ListOptions struct {
+	// Paths are files or glob patterns, listed in order.
+	Paths []string
+	// Dirs are searched for files in a registered format, or for files
+	// matching Include when it's set.
+	Dirs      []string
+	Recursive bool
+	// Include and Exclude are glob patterns matched against the path or file
+	// name of files in Dirs. Exclude wins over Include.
+	Include []string
+	Exclude []string
+}
+
+// Warning is a problem found in an input file that didn't stop it from being
+// read.
+type Warning struct {
+	Path    string
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Path, w.Message)
+}
+
+// ListFiles returns the files given by options, in the order they're given. A
+// file listed more than once is only returned once.
+func ListFiles(options ListOptions) ([]string, error) {
+	paths, err := input.Expand(options.Paths)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dir := range options.Dirs {
+		dirPaths, err := input.Walk(dir, options.Recursive, options.Include, options.Exclude)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range dirPaths {
+			if _, ok := LookupReader(Format(path)); ok || len(options.Include) > 0 {
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	var files []string
+	seen := map[string]bool{}
+	for _, path := range paths {
+		if !seen[filepath.Clean(path)] {
+			seen[filepath.Clean(path)] = true
+			files = append(files, path)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no input files found")
+	}
+	return files, nil
+}
+
+// ReadFiles reads paths concurrently on up to workers goroutines, or
+// GOMAXPROCS when workers is 0. Properties and validation warnings are
+// returned in file order, and an error is reported for the first file in
+// order that failed, so the result doesn't depend on scheduling. Warnings are
+// returned even when reading fails.
+func ReadFiles(ctx context.Context, paths []string, options ReadOptions, workers int) ([]Property, []Warning, error) {
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	results := make([][]Property, len(paths))
+	messages := make([][]string, len(paths))
+	errs := make([]error, len(paths))
+
+	// A file that fails cancels the reads of the files after it and no more
+	// files are started, while the files before it are read to the end in
+	// case one of them fails too.
+	var mu sync.Mutex
+	failed := len(paths)
+	cancels := make([]context.CancelFunc, len(paths))
+	defer func() {
+		for _, cancel := range cancels {
+			if cancel != nil {
+				cancel()
+			}
+		}
+	}()
+
+	var group errgroup.Group
+	group.SetLimit(workers)
+	for i, path := range paths {
+		mu.Lock()
+		stop := failed < len(paths)
+		var fileCtx context.Context
+		if !stop {
+			fileCtx, cancels[i] = context.WithCancel(ctx)
+		}
+		mu.Unlock()
+		if stop {
+			break
+		}
+
+		group.Go(func() error {
+			properties, err := ReadFile(fileCtx, path, options)
+			if err != nil {
+				errs[i] = err
+				mu.Lock()
+				if i < failed {
+					failed = i
+					for _, cancel := range cancels[i+1:] {
+						if cancel != nil {
+							cancel()
+						}
+					}
+				}
+				mu.Unlock()
+				return nil
+			}
+			results[i], messages[i] = properties, input.Validate(properties)
+			return nil
+		})
+	}
+	group.Wait()
+
+	var warnings []Warning
+	for i, path := range paths {
+		for _, message := range messages[i] {
+			warnings = append(warnings, Warning{path, message})
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, warnings, err
+	}
+	if failed < len(paths) {
+		return nil, warnings, errs[failed]
+	}
+
+	var properties []Property
+	for _, result := range results {
+		properties = append(properties, result...)
+	}
+	return properties, warnings, nil
+}
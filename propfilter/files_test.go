@@ -0,0 +1,119 @@
+package propfilter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestListFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.json", "b.csv", "notes.txt", "sub/c.json"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	join := func(names ...string) []string {
+		paths := make([]string, len(names))
+		for i, name := range names {
+			paths[i] = filepath.Join(dir, name)
+		}
+		return paths
+	}
+
+	tests := []struct {
+		name      string
+		options   ListOptions
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "Paths and globs in order",
+			options:  ListOptions{Paths: []string{filepath.Join(dir, "b.csv"), filepath.Join(dir, "*.json")}},
+			expected: join("b.csv", "a.json"),
+		},
+		{
+			name:     "Dir lists registered formats only",
+			options:  ListOptions{Dirs: []string{dir}},
+			expected: join("a.json", "b.csv"),
+		},
+		{
+			name:     "Recursive dir",
+			options:  ListOptions{Dirs: []string{dir}, Recursive: true},
+			expected: join("a.json", "b.csv", "sub/c.json"),
+		},
+		{
+			name:     "Include lists any matching file",
+			options:  ListOptions{Dirs: []string{dir}, Include: []string{"*.txt"}},
+			expected: join("notes.txt"),
+		},
+		{
+			name:     "Duplicates listed once",
+			options:  ListOptions{Paths: []string{filepath.Join(dir, "a.json")}, Dirs: []string{dir}, Exclude: []string{"b.csv"}},
+			expected: join("a.json"),
+		},
+		{
+			name:      "No files",
+			options:   ListOptions{Dirs: []string{dir}, Include: []string{"*.xlsx"}},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := ListFiles(test.options)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestReadFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	first := write("first.json", `[{"squareFootage": 1, "lighting": "dim", "ammenities": {}}]`)
+	second := write("second.json", `[{"squareFootage": 2, "lighting": "bright", "ammenities": {}}]`)
+	broken := write("broken.json", `{`)
+	alsoBroken := write("also-broken.csv", "price\n1\n")
+
+	for i := 0; i < 10; i++ {
+		properties, warnings, err := ReadFiles(context.Background(), []string{first, second}, ReadOptions{}, 2)
+		if err != nil {
+			t.Fatalf("did not expect error but got: %v", err)
+		}
+		if len(properties) != 2 || properties[0].Source != first || properties[1].Source != second {
+			t.Fatalf("expected properties in file order, got %v", properties)
+		}
+		if len(warnings) != 2 || warnings[0].Path != first || warnings[1].Path != second {
+			t.Fatalf("expected warnings in file order, got %v", warnings)
+		}
+
+		_, _, err = ReadFiles(context.Background(), []string{first, broken, alsoBroken}, ReadOptions{}, 3)
+		if err == nil || !strings.Contains(err.Error(), broken) {
+			t.Fatalf("expected error for %s, got %v", broken, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package propfilter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+	"github.com/ramirofarias/prop-filter-cli/output"
+)
+
+const (
+	CompressionGzip = output.CompressionGzip
+	CompressionZstd = output.CompressionZstd
+
+	// AmmenitiesKey and AmenitiesKey are the values of
+	// WriteOptions.AmmenitiesKey, the name amenities are written under.
+	AmmenitiesKey = models.AmmenitiesKey
+	AmenitiesKey  = models.AmenitiesKey
+
+	KMLStyleLighting = output.KMLStyleLighting
+	KMLStylePrice    = output.KMLStylePrice
+)
+
+// ParseFields parses a comma-separated list of output fields for
+// WriteOptions.Fields, each optionally renamed with "field:Name".
+func ParseFields(s string) ([]Field, error) {
+	return output.ParseFields(s)
+}
+
+// CheckWriteOptions reports invalid options before any property is read, so
+// a run doesn't fail only once it gets to writing.
+func CheckWriteOptions(options WriteOptions) error {
+	if options.AmmenitiesKey != "" && options.AmmenitiesKey != AmmenitiesKey && options.AmmenitiesKey != AmenitiesKey {
+		return fmt.Errorf("invalid amenities key: %s", options.AmmenitiesKey)
+	}
+	switch options.Compression {
+	case "", CompressionGzip, CompressionZstd:
+	default:
+		return fmt.Errorf("invalid compression: %s", options.Compression)
+	}
+	return output.ValidateKMLOptions(options)
+}
+
+// PrintTable prints properties to stdout as an aligned table sized to the
+// terminal, with colors when stdout is a terminal and NO_COLOR isn't set.
+func PrintTable(ctx context.Context, properties []Property, options WriteOptions) error {
+	return output.ToTableStdOut(ctx, properties, options)
+}
+
+// ParseTemplate parses a text/template with the helper functions available to
+// WriteTemplate.
+func ParseTemplate(name, text string) (*template.Template, error) {
+	return output.ParseTemplate(name, text)
+}
+
+// WriteTemplate renders tmpl with properties to w.
+func WriteTemplate(ctx context.Context, w io.Writer, properties []Property, tmpl *template.Template) error {
+	return output.WriteTemplate(ctx, w, properties, tmpl)
+}
+
+// WriteTemplateFile renders tmpl with properties to path, like WriteFile.
+func WriteTemplateFile(ctx context.Context, path string, properties []Property, tmpl *template.Template, options WriteOptions) error {
+	return output.ToTemplateFile(ctx, properties, path, tmpl, options)
+}
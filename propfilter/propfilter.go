@@ -0,0 +1,54 @@
+package propfilter
+
+import (
+	"github.com/ramirofarias/prop-filter-cli/filter"
+	"github.com/ramirofarias/prop-filter-cli/models"
+	"github.com/ramirofarias/prop-filter-cli/output"
+)
+
+type (
+	Property   = models.Property
+	Filter     = filter.Filter
+	Comparison = filter.Comparison
+	Matcher    = filter.Matcher
+	Field      = output.Field
+
+	// WriteOptions control which fields are written and how. Formats ignore
+	// the options that don't apply to them.
+	WriteOptions = output.Options
+)
+
+const (
+	OperatorLess           = filter.OperatorLess
+	OperatorLessOrEqual    = filter.OperatorLessOrEqual
+	OperatorGreater        = filter.OperatorGreater
+	OperatorGreaterOrEqual = filter.OperatorGreaterOrEqual
+	OperatorEqual          = filter.OperatorEqual
+	OperatorNotEqual       = filter.OperatorNotEqual
+	OperatorOneOf          = filter.OperatorOneOf
+
+	UnknownAmmenitiesAsFalse = filter.UnknownAmmenitiesAsFalse
+	UnknownAmmenitiesExclude = filter.UnknownAmmenitiesExclude
+)
+
+// ReadOptions control how properties are read. Formats ignore the options
+// that don't apply to them.
+type ReadOptions struct {
+	// Sheet is the XLSX sheet to read, the first one when empty.
+	Sheet string
+	// SQLiteTable is the SQLite table to read, "properties" when empty.
+	SQLiteTable string
+	// SQLiteQuery is a SQLite query to read instead of a table.
+	SQLiteQuery string
+
+	// Filter and Columns are hints that let formats that support it skip data
+	// that can't match Filter, and only read the listed Property fields (all
+	// of them when nil). The properties read still need to be filtered.
+	Filter  Filter
+	Columns []string
+}
+
+// Compile compiles f into a Matcher that can be reused across datasets.
+func Compile(f Filter) (Matcher, error) {
+	return filter.Compile(f)
+}
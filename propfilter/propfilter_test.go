@@ -0,0 +1,163 @@
+package propfilter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testProperties = []Property{
+	{SquareFootage: 1200, Lighting: "high", Price: 250000, Rooms: 3, Bathrooms: 2, Location: [2]float64{34.05, -118.24}, Description: "Bright loft", Ammenities: map[string]bool{"pool": true, "garage": false}},
+	{SquareFootage: 800, Lighting: "low", Price: 150000, Rooms: 1, Bathrooms: 1, Location: [2]float64{40.71, -74.01}, Description: "Cozy studio", Ammenities: map[string]bool{"gym": true}},
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	for _, format := range []string{"json", "csv", "geojson", "xlsx", "parquet", "sqlite"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(context.Background(), &buf, format, testProperties, WriteOptions{}); err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+
+			properties, err := Read(context.Background(), &buf, format, ReadOptions{})
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+			if !reflect.DeepEqual(properties, testProperties) {
+				t.Errorf("expected %v, got %v", testProperties, properties)
+			}
+		})
+	}
+}
+
+func TestFileRoundTrip(t *testing.T) {
+	for _, name := range []string{"out.json", "out.CSV", "out.csv.gz", "out.parquet.zst", "out.db"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := WriteFile(context.Background(), path, testProperties, WriteOptions{}); err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+
+			properties, err := ReadFile(context.Background(), path, ReadOptions{})
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+			for i := range properties {
+				if properties[i].Source != path {
+					t.Errorf("expected source %s, got %s", path, properties[i].Source)
+				}
+				properties[i].Source = ""
+			}
+			if !reflect.DeepEqual(properties, testProperties) {
+				t.Errorf("expected %v, got %v", testProperties, properties)
+			}
+		})
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		delete(readers, "tsv")
+		delete(writers, "tsv")
+	})
+
+	RegisterWriter("tsv", WriterFunc(func(ctx context.Context, w io.Writer, properties []Property, options WriteOptions) error {
+		for _, property := range properties {
+			if _, err := fmt.Fprintf(w, "%g\t%s\n", property.Price, property.Lighting); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	RegisterReader("TSV", ReaderFunc(func(ctx context.Context, r io.Reader, options ReadOptions) ([]Property, error) {
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+
+		var properties []Property
+		for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+			var property Property
+			if _, err := fmt.Sscanf(line, "%g\t%s", &property.Price, &property.Lighting); err != nil {
+				return nil, err
+			}
+			properties = append(properties, property)
+		}
+		return properties, nil
+	}))
+
+	path := filepath.Join(t.TempDir(), "out.tsv.gz")
+	if err := WriteFile(context.Background(), path, testProperties, WriteOptions{}); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+
+	properties, err := ReadFile(context.Background(), path, ReadOptions{})
+	if err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+	expected := []Property{
+		{Price: 250000, Lighting: "high", Source: path},
+		{Price: 150000, Lighting: "low", Source: path},
+	}
+	if !reflect.DeepEqual(properties, expected) {
+		t.Errorf("expected %v, got %v", expected, properties)
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+	if err := WriteFile(context.Background(), path, testProperties, WriteOptions{}); err == nil {
+		t.Errorf("expected error but got nil")
+	}
+	if _, err := ReadFile(context.Background(), path, ReadOptions{}); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("expected error naming %s, got %v", path, err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := map[string]string{
+		"listings.csv":         "csv",
+		"data/listings.JSON":   "json",
+		"listings.parquet.zst": "parquet",
+		"listings.csv.gz":      "csv",
+		"listings":             "",
+	}
+
+	for path, expected := range tests {
+		if format := Format(path); format != expected {
+			t.Errorf("%s: expected %q, got %q", path, expected, format)
+		}
+	}
+}
+
+func TestCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, name := range []string{"out.json", "out.csv.gz", "out.sqlite"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := WriteFile(ctx, path, testProperties, WriteOptions{}); err == nil {
+				t.Errorf("expected error but got nil")
+			}
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("expected partial output to be removed, got %v", err)
+			}
+
+			if err := WriteFile(context.Background(), path, testProperties, WriteOptions{}); err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+			if _, err := ReadFile(ctx, path, ReadOptions{}); err == nil {
+				t.Errorf("expected error but got nil")
+			}
+		})
+	}
+}
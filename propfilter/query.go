@@ -0,0 +1,130 @@
+package propfilter
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/ramirofarias/prop-filter-cli/filter"
+	"github.com/ramirofarias/prop-filter-cli/index"
+	"github.com/ramirofarias/prop-filter-cli/output"
+	"github.com/ramirofarias/prop-filter-cli/search"
+)
+
+// Query selects and orders properties.
+type Query struct {
+	Filter Filter
+	// Search ranks properties by how relevant their description is to this
+	// text (BM25), dropping those that match none of its terms.
+	Search string
+	// Workers is the number of chunks of properties filtered at once, or
+	// GOMAXPROCS when 0.
+	Workers int
+}
+
+// Apply returns the properties that match q, ranked when q.Search is set and
+// in their original order otherwise.
+func Apply(ctx context.Context, properties []Property, q Query) ([]Property, error) {
+	return apply(ctx, properties, nil, q)
+}
+
+func apply(ctx context.Context, properties []Property, textIndex *search.Index, q Query) ([]Property, error) {
+	if q.Search != "" {
+		if textIndex == nil {
+			textIndex = search.FromProperties(properties)
+		}
+		properties = search.Rank(properties, textIndex, q.Search)
+	}
+
+	workers := q.Workers
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	filteredProperties, err := filter.FilterPropertiesContext(ctx, properties, q.Filter, workers)
+	if err != nil {
+		return nil, fmt.Errorf("error filtering properties: %v", err)
+	}
+	return filteredProperties, nil
+}
+
+// ApplyIndex reads the index file at path, written by BuildIndex, and returns
+// the properties that match q like Apply. Only the properties the index can't
+// rule out are decoded. It fails when the indexed file has changed, and warns
+// when that can't be checked.
+func ApplyIndex(ctx context.Context, path string, q Query) ([]Property, []Warning, error) {
+	idx, err := index.Read(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading index file: %v", err)
+	}
+
+	var warnings []Warning
+	stale, err := idx.Stale()
+	if err != nil {
+		warnings = append(warnings, Warning{path, fmt.Sprintf("could not check if index is up to date: %v", err)})
+	}
+	if stale {
+		return nil, warnings, fmt.Errorf("index file %s is stale: %s has changed since it was indexed", path, idx.Source)
+	}
+
+	if q.Search != "" {
+		properties, err := apply(ctx, idx.Properties(nil), idx.Text, q)
+		return properties, warnings, err
+	}
+
+	candidates, err := idx.Candidates(q.Filter)
+	if err != nil {
+		return nil, warnings, fmt.Errorf("error filtering properties: %v", err)
+	}
+	properties, err := apply(ctx, idx.Properties(candidates), nil, q)
+	return properties, warnings, err
+}
+
+// BuildIndex reads the input file at path and writes an index of it to
+// indexPath for ApplyIndex. An existing index file is only replaced when
+// overwrite is set.
+func BuildIndex(ctx context.Context, path string, options ReadOptions, indexPath string, overwrite bool) ([]Warning, error) {
+	properties, warnings, err := ReadFiles(ctx, []string{path}, options, 1)
+	if err != nil {
+		return warnings, err
+	}
+
+	idx, err := index.Build(properties, path)
+	if err != nil {
+		return warnings, fmt.Errorf("error building index: %v", err)
+	}
+
+	if err := index.Write(ctx, idx, indexPath, overwrite); err != nil {
+		return warnings, fmt.Errorf("error writing index file: %v", err)
+	}
+	return warnings, nil
+}
+
+// Columns returns the Property fields needed to run q and write fields, for
+// ReadOptions.Columns. Lighting is always included so input validation
+// doesn't flag missing values.
+func Columns(q Query, fields []Field) []string {
+	columns := append(output.Columns(fields), "lighting")
+	if len(q.Filter.SquareFootage) > 0 {
+		columns = append(columns, "squareFootage")
+	}
+	if len(q.Filter.Price) > 0 {
+		columns = append(columns, "price")
+	}
+	if len(q.Filter.Rooms) > 0 {
+		columns = append(columns, "rooms")
+	}
+	if len(q.Filter.Bathrooms) > 0 {
+		columns = append(columns, "bathrooms")
+	}
+	if len(q.Filter.Distance) > 0 {
+		columns = append(columns, "location")
+	}
+	if len(q.Filter.Keywords) > 0 || q.Search != "" {
+		columns = append(columns, "description")
+	}
+	if len(q.Filter.Ammenities) > 0 || len(q.Filter.AmmenitiesAny) > 0 || len(q.Filter.AmmenitiesNone) > 0 {
+		columns = append(columns, "ammenities")
+	}
+
+	return columns
+}
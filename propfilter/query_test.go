@@ -0,0 +1,109 @@
+package propfilter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    Query
+		expected []float64
+	}{
+		{
+			name:     "Filter keeps order",
+			query:    Query{Filter: Filter{Price: []Comparison{{Operator: OperatorLess, Value: 300000}}}},
+			expected: []float64{250000, 150000},
+		},
+		{
+			name:     "Search ranks and drops non-matching",
+			query:    Query{Search: "cozy studio"},
+			expected: []float64{150000},
+		},
+		{
+			name:     "Search and filter",
+			query:    Query{Search: "loft studio", Filter: Filter{Ammenities: []string{"pool"}}},
+			expected: []float64{250000},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			properties, err := Apply(context.Background(), testProperties, test.query)
+			if err != nil {
+				t.Fatalf("did not expect error but got: %v", err)
+			}
+			var prices []float64
+			for _, property := range properties {
+				prices = append(prices, property.Price)
+			}
+			if !reflect.DeepEqual(prices, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, prices)
+			}
+		})
+	}
+}
+
+func TestApplyIndex(t *testing.T) {
+	dir := t.TempDir()
+	inputPath := filepath.Join(dir, "listings.json")
+	indexPath := filepath.Join(dir, "listings.idx")
+	if err := WriteFile(context.Background(), inputPath, testProperties, WriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := BuildIndex(context.Background(), inputPath, ReadOptions{}, indexPath, false); err != nil {
+		t.Fatalf("did not expect error but got: %v", err)
+	}
+	if _, err := BuildIndex(context.Background(), inputPath, ReadOptions{}, indexPath, false); err == nil {
+		t.Errorf("expected error for existing index but got nil")
+	}
+
+	for _, query := range []Query{
+		{Filter: Filter{Ammenities: []string{"gym"}}},
+		{Search: "bright loft"},
+	} {
+		properties, warnings, err := ApplyIndex(context.Background(), indexPath, query)
+		if err != nil || len(warnings) > 0 {
+			t.Fatalf("did not expect error but got: %v, %v", err, warnings)
+		}
+		expected, _ := Apply(context.Background(), testProperties, query)
+		if len(properties) != 1 || properties[0].Description != expected[0].Description {
+			t.Errorf("expected %v, got %v", expected, properties)
+		}
+	}
+
+	if err := os.WriteFile(inputPath, []byte("[]"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ApplyIndex(context.Background(), indexPath, Query{}); err == nil {
+		t.Errorf("expected error for stale index but got nil")
+	}
+}
+
+func TestCheckWriteOptions(t *testing.T) {
+	tests := []struct {
+		options   WriteOptions
+		expectErr bool
+	}{
+		{options: WriteOptions{}},
+		{options: WriteOptions{AmmenitiesKey: AmenitiesKey, Compression: CompressionZstd, KMLStyle: KMLStylePrice}},
+		{options: WriteOptions{AmmenitiesKey: "features"}, expectErr: true},
+		{options: WriteOptions{Compression: "bzip2"}, expectErr: true},
+		{options: WriteOptions{KMLNameFields: []string{"garden"}}, expectErr: true},
+	}
+
+	for _, test := range tests {
+		err := CheckWriteOptions(test.options)
+		if test.expectErr && err == nil {
+			t.Errorf("expected error for %+v but got nil", test.options)
+		}
+		if !test.expectErr && err != nil {
+			t.Errorf("did not expect error for %+v but got: %v", test.options, err)
+		}
+	}
+}
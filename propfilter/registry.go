@@ -0,0 +1,119 @@
+package propfilter
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ramirofarias/prop-filter-cli/parser"
+)
+
+// Reader decodes properties from a stream. Readers should stop and return an
+// error once ctx is done, as should Writers.
+type Reader interface {
+	Read(ctx context.Context, r io.Reader, options ReadOptions) ([]Property, error)
+}
+
+// Writer encodes properties to a stream.
+type Writer interface {
+	Write(ctx context.Context, w io.Writer, properties []Property, options WriteOptions) error
+}
+
+// FileReader is implemented by Readers that read files better than streams,
+// for example by seeking. ReadFile uses it instead of Read.
+type FileReader interface {
+	Reader
+	ReadFile(ctx context.Context, path string, options ReadOptions) ([]Property, error)
+}
+
+// FileWriter is implemented by Writers that write files differently than
+// streams, for example by updating an existing file. WriteFile uses it instead
+// of Write.
+type FileWriter interface {
+	Writer
+	WriteFile(ctx context.Context, path string, properties []Property, options WriteOptions) error
+}
+
+type ReaderFunc func(ctx context.Context, r io.Reader, options ReadOptions) ([]Property, error)
+
+func (f ReaderFunc) Read(ctx context.Context, r io.Reader, options ReadOptions) ([]Property, error) {
+	return f(ctx, r, options)
+}
+
+type WriterFunc func(ctx context.Context, w io.Writer, properties []Property, options WriteOptions) error
+
+func (f WriterFunc) Write(ctx context.Context, w io.Writer, properties []Property, options WriteOptions) error {
+	return f(ctx, w, properties, options)
+}
+
+var (
+	registryMu sync.RWMutex
+	readers    = map[string]Reader{}
+	writers    = map[string]Writer{}
+)
+
+// RegisterReader makes reader available for format, replacing any reader
+// already registered for it. Format names are file extensions without the
+// dot, and are case-insensitive.
+func RegisterReader(format string, reader Reader) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	readers[strings.ToLower(format)] = reader
+}
+
+// RegisterWriter makes writer available for format, like RegisterReader.
+func RegisterWriter(format string, writer Writer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	writers[strings.ToLower(format)] = writer
+}
+
+func LookupReader(format string) (Reader, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	reader, ok := readers[strings.ToLower(format)]
+	return reader, ok
+}
+
+func LookupWriter(format string) (Writer, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	writer, ok := writers[strings.ToLower(format)]
+	return writer, ok
+}
+
+// ReaderFormats returns the formats that can be read, sorted.
+func ReaderFormats() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return sortedKeys(readers)
+}
+
+// WriterFormats returns the formats that can be written, sorted.
+func WriterFormats() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return sortedKeys(writers)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Format returns the format of a path from its extension, ignoring a trailing
+// compression extension: "file.csv.gz" is "csv". It's empty when the path has
+// no extension.
+func Format(path string) string {
+	if parser.ParseCompression(path) != "" {
+		path = path[:strings.LastIndex(path, ".")]
+	}
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+}
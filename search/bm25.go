@@ -0,0 +1,116 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+type Posting struct {
+	Doc       int
+	Frequency int
+}
+
+type Index struct {
+	Postings      map[string][]Posting
+	DocLengths    []int
+	AverageLength float64
+}
+
+func NewIndex(documents []string) *Index {
+	index := &Index{
+		Postings:   map[string][]Posting{},
+		DocLengths: make([]int, len(documents)),
+	}
+
+	totalLength := 0
+	for doc, text := range documents {
+		terms := Tokenize(text)
+		index.DocLengths[doc] = len(terms)
+		totalLength += len(terms)
+
+		frequencies := map[string]int{}
+		for _, term := range terms {
+			frequencies[term]++
+		}
+		for term, frequency := range frequencies {
+			index.Postings[term] = append(index.Postings[term], Posting{Doc: doc, Frequency: frequency})
+		}
+	}
+
+	if len(documents) > 0 {
+		index.AverageLength = float64(totalLength) / float64(len(documents))
+	}
+
+	return index
+}
+
+func FromProperties(properties []models.Property) *Index {
+	descriptions := make([]string, len(properties))
+	for i, property := range properties {
+		descriptions[i] = property.Description
+	}
+	return NewIndex(descriptions)
+}
+
+func (index *Index) Score(query string) []float64 {
+	scores := make([]float64, len(index.DocLengths))
+	docCount := float64(len(index.DocLengths))
+
+	seen := map[string]bool{}
+	for _, term := range Tokenize(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		postings := index.Postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+
+		docFrequency := float64(len(postings))
+		idf := math.Log(1 + (docCount-docFrequency+0.5)/(docFrequency+0.5))
+		for _, posting := range postings {
+			frequency := float64(posting.Frequency)
+			lengthRatio := float64(index.DocLengths[posting.Doc]) / index.AverageLength
+			scores[posting.Doc] += idf * frequency * (k1 + 1) / (frequency + k1*(1-b+b*lengthRatio))
+		}
+	}
+
+	return scores
+}
+
+func Rank(properties []models.Property, index *Index, query string) []models.Property {
+	scores := index.Score(query)
+
+	var ranked []models.Property
+	for i, property := range properties {
+		if scores[i] == 0 {
+			continue
+		}
+		score := scores[i]
+		property.Score = &score
+		ranked = append(ranked, property)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return *ranked[i].Score > *ranked[j].Score
+	})
+
+	return ranked
+}
+
+func Tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
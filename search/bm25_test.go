@@ -0,0 +1,80 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ramirofarias/prop-filter-cli/models"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"Quiet family home", []string{"quiet", "family", "home"}},
+		{"3-bedroom home, near schools.", []string{"3", "bedroom", "home", "near", "schools"}},
+		{"  ", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := Tokenize(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	index := NewIndex([]string{
+		"Quiet family home near schools",
+		"Loft downtown near bars",
+		"Quiet quiet quiet cabin",
+		"Studio",
+	})
+
+	scores := index.Score("quiet schools")
+	if scores[0] <= scores[2] {
+		t.Errorf("expected doc matching both terms to outrank doc matching one, got %v", scores)
+	}
+	if scores[1] != 0 || scores[3] != 0 {
+		t.Errorf("expected non-matching docs to score 0, got %v", scores)
+	}
+
+	scores = index.Score("near")
+	if scores[0] <= 0 || scores[1] <= 0 {
+		t.Errorf("expected both docs containing the term to score, got %v", scores)
+	}
+	if scores[1] <= scores[0] {
+		t.Errorf("expected shorter doc to score higher for the same term frequency, got %v", scores)
+	}
+}
+
+func TestRank(t *testing.T) {
+	properties := []models.Property{
+		{Description: "Loft downtown near bars"},
+		{Description: "Quiet family home near schools"},
+		{Description: "Studio"},
+	}
+
+	result := Rank(properties, FromProperties(properties), "quiet family home")
+	if len(result) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(result))
+	}
+	if result[0].Description != properties[1].Description {
+		t.Errorf("expected %q, got %q", properties[1].Description, result[0].Description)
+	}
+	if result[0].Score == nil || *result[0].Score <= 0 {
+		t.Errorf("expected a positive score, got %v", result[0].Score)
+	}
+
+	result = Rank(properties, FromProperties(properties), "near")
+	if len(result) != 2 || result[0].Description != properties[0].Description {
+		t.Errorf("expected results ordered by score, got %v", result)
+	}
+	if properties[0].Score != nil {
+		t.Errorf("expected input properties to be left untouched")
+	}
+}